@@ -0,0 +1,29 @@
+package rockside
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var (
+	smartWalletABI = &lazyABI{json: SmartWalletABI}
+	forwarderABI   = &lazyABI{json: ForwarderABI}
+)
+
+// lazyABI parses a JSON ABI on first use.
+type lazyABI struct {
+	json string
+
+	once   sync.Once
+	parsed abi.ABI
+	err    error
+}
+
+func (l *lazyABI) get() (abi.ABI, error) {
+	l.once.Do(func() {
+		l.parsed, l.err = abi.JSON(strings.NewReader(l.json))
+	})
+	return l.parsed, l.err
+}
@@ -2,6 +2,7 @@ package rockside
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,50 +10,12 @@ import (
 	"log"
 	"math/big"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
-var (
-	Testnet           Network = "ropsten"
-	Mainnet           Network = "mainnet"
-	PoaNetworkMainnet Network = "poanetwork"
-	GethPrivateNet    Network = "gethprivate"
-)
-
-type Network string
-
-func (n Network) ExplorerURL() string {
-	switch n {
-	case Mainnet:
-		return "https://etherscan.io"
-	case Testnet:
-		return fmt.Sprintf("https://%s.etherscan.io", n)
-	case PoaNetworkMainnet:
-		return fmt.Sprintf("https://blockscout.com/poa/core")
-	default:
-		return ""
-	}
-}
-
-func (n Network) ChainID() *big.Int {
-	switch n {
-	case Mainnet:
-		return big.NewInt(1)
-	case Testnet:
-		return big.NewInt(3)
-	case PoaNetworkMainnet:
-		return big.NewInt(99)
-	case GethPrivateNet:
-		return big.NewInt(1337)
-	default:
-		return big.NewInt(0)
-	}
-}
-
 type endpoint struct {
 	client *Client
 }
@@ -60,8 +23,16 @@ type endpoint struct {
 type Client struct {
 	rocksideURL    *url.URL
 	network        Network
-	logger         *log.Logger
+	networkPath    string
+	chainID        *big.Int
+	chainVerified  bool
+	redactor       *redactor
+	interceptors   []Interceptor
+	logger         Logger
 	authHTTPClient *http.Client
+	retry          RetryPolicy
+	userAgent      string
+	simulate       bool
 
 	RPCClient *RPCClient
 
@@ -73,51 +44,113 @@ type Client struct {
 	Relay        *Relay
 }
 
-const defaultRocksideURL = "https://api.rockside.io"
+const (
+	defaultRocksideURL = "https://api.rockside.io"
+	defaultUserAgent   = "rockside-sdk-go"
+)
 
-func NewClientFromAPIKey(apiKey string, net Network, rocksideBaseURL ...string) (*Client, error) {
-	if len(apiKey) == 0 {
-		return nil, fmt.Errorf("init client: no API key found. Try with env variable ROCKSIDE_API_KEY")
+// Auth authenticates the requests sent to Rockside.
+type Auth interface {
+	Authenticate(req *http.Request)
+}
+
+// APIKey authenticates requests with a Rockside API key.
+type APIKey string
+
+func (k APIKey) Authenticate(req *http.Request) {
+	req.Header.Set("apikey", string(k))
+}
+
+func (k APIKey) validate() error {
+	if len(k) == 0 {
+		return fmt.Errorf("init client: no API key found. Try with env variable ROCKSIDE_API_KEY")
 	}
-	if len(apiKey) != 32 {
-		return nil, fmt.Errorf("init client: expected length of API key to be 32 but got %d", len(apiKey))
+	if len(k) != 32 {
+		return fmt.Errorf("init client: expected length of API key to be 32 but got %d", len(k))
 	}
+	return nil
+}
 
-	baseURL := defaultRocksideURL
-	if len(rocksideBaseURL) > 0 {
-		baseURL = rocksideBaseURL[0]
+// Token authenticates requests with a Rockside token and its associated origin.
+type Token struct {
+	Token  string
+	Origin string
+}
+
+func (t Token) Authenticate(req *http.Request) {
+	req.Header.Set("Origin", t.Origin)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.Token))
+}
+
+// New returns a client authenticated with the given auth on the given network.
+func New(auth Auth, net Network, opts ...Option) (*Client, error) {
+	o := &options{baseURL: defaultRocksideURL, transport: http.DefaultTransport}
+	for _, opt := range opts {
+		opt(o)
 	}
 
-	authFunc := func(req *http.Request) {
-		req.Header.Set("apikey", apiKey)
+	if v, ok := auth.(interface{ validate() error }); ok {
+		if err := v.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient := &http.Client{
+		Transport: &authenticatedHeaderTransport{auth: auth, base: o.transport},
+		Timeout:   o.timeout,
+	}
+
+	c, err := newClient(httpClient, net, o.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.userAgentSuffix != "" {
+		c.userAgent = fmt.Sprintf("%s %s", defaultUserAgent, o.userAgentSuffix)
+	}
+	if o.logger != nil {
+		c.SetStructuredLogger(o.logger)
+	}
+	if o.retry != nil {
+		c.SetRetryPolicy(*o.retry)
+	}
+	c.interceptors = o.interceptors
+	c.simulate = o.simulate
+	c.redactor.addHeaders(o.redactedHeaders...)
+	c.redactor.addFields(o.redactedFields...)
+	if o.verifyChainID {
+		if err := c.verifyChainID(context.Background()); err != nil {
+			return nil, err
+		}
 	}
 
-	return newClient(&http.Client{Transport: &authenticatedHeaderTransport{authFunc}}, net, baseURL)
+	return c, nil
 }
 
-func NewClientFromToken(token, origin string, net Network, rocksideBaseURL ...string) (*Client, error) {
-	baseURL := defaultRocksideURL
+func NewClientFromAPIKey(apiKey string, net Network, rocksideBaseURL ...string) (*Client, error) {
+	var opts []Option
 	if len(rocksideBaseURL) > 0 {
-		baseURL = rocksideBaseURL[0]
+		opts = append(opts, WithBaseURL(rocksideBaseURL[0]))
 	}
 
-	authFunc := func(req *http.Request) {
-		req.Header.Set("Origin", origin)
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return New(APIKey(apiKey), net, opts...)
+}
+
+func NewClientFromToken(token, origin string, net Network, rocksideBaseURL ...string) (*Client, error) {
+	var opts []Option
+	if len(rocksideBaseURL) > 0 {
+		opts = append(opts, WithBaseURL(rocksideBaseURL[0]))
 	}
 
-	return newClient(&http.Client{Transport: &authenticatedHeaderTransport{authFunc}}, net, baseURL)
+	return New(Token{Token: token, Origin: origin}, net, opts...)
 }
 
 func newClient(authenticatedHTTPClient *http.Client, net Network, baseURL string) (*Client, error) {
-	var network Network
-
-	switch net {
-	case Mainnet, Testnet:
-		network = net
-	default:
-		return nil, fmt.Errorf("init client: invalid network '%s' for client. Expecting: %s or %s", net, Mainnet, Testnet)
+	networkCfg, ok := LookupNetwork(net)
+	if !ok {
+		return nil, fmt.Errorf("init client: invalid network '%s' for client. Expecting one of: %s", net, RegisteredNetworks())
 	}
+	network := networkCfg.Network
 
 	u, err := url.Parse(baseURL)
 	if err != nil {
@@ -127,26 +160,40 @@ func newClient(authenticatedHTTPClient *http.Client, net Network, baseURL string
 		return nil, fmt.Errorf("init client: expected base URL with HTTPS scheme but got URL '%s'", u)
 	}
 
-	rpcEndpoint, err := url.Parse(fmt.Sprintf("%s/ethereum/%s/jsonrpc", u, network))
+	rpcEndpoint, err := url.Parse(fmt.Sprintf("%s/ethereum/%s/jsonrpc", u, networkCfg.PathSegment))
 	if err != nil {
 		return nil, fmt.Errorf("cannot build RPC URL from %s (%s)", u, network)
 	}
 
-	rpcClient, err := rpc.DialHTTPWithClient(rpcEndpoint.String(), authenticatedHTTPClient)
+	c := &Client{
+		rocksideURL: u,
+		network:     network,
+		networkPath: networkCfg.PathSegment,
+		chainID:     networkCfg.ChainID,
+		logger:      nopLogger{},
+		retry:       DefaultRetryPolicy,
+		userAgent:   defaultUserAgent,
+		redactor:    newRedactor(),
+	}
+
+	base := authenticatedHTTPClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpClient := *authenticatedHTTPClient
+	httpClient.Transport = &interceptorTransport{client: c, base: &loggingTransport{client: c, base: base}}
+	c.authHTTPClient = &httpClient
+
+	rpcClient, err := rpc.DialHTTPWithClient(rpcEndpoint.String(), c.authHTTPClient)
 	if err != nil {
 		return nil, fmt.Errorf("cannot RPC dial with custom HTTP client: %s", err)
 	}
 
-	c := &Client{
-		RPCClient: &RPCClient{
-			endpoint:       rpcEndpoint,
-			authHTTPClient: authenticatedHTTPClient,
-			Client:         ethclient.NewClient(rpcClient),
-		},
-		authHTTPClient: authenticatedHTTPClient,
-		rocksideURL:    u,
+	c.RPCClient = &RPCClient{
+		endpoint:       rpcEndpoint,
 		network:        network,
-		logger:         log.New(ioutil.Discard, "", 0),
+		authHTTPClient: c.authHTTPClient,
+		Client:         ethclient.NewClient(rpcClient),
 	}
 
 	c.EOA = &EOA{c}
@@ -159,10 +206,45 @@ func newClient(authenticatedHTTPClient *http.Client, net Network, baseURL string
 	return c, nil
 }
 
+// SetLogger logs every level, including request and response dumps, to the
+// given standard library logger.
 func (c *Client) SetLogger(l *log.Logger) {
+	c.logger = StdLogger(l, LevelDebug)
+}
+
+// SetStructuredLogger sets the logger receiving the REST and JSON-RPC client logs.
+func (c *Client) SetStructuredLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
 	c.logger = l
 }
 
+func (c *Client) verifyChainID(ctx context.Context) error {
+	nodeChainID, err := c.RPCClient.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("init client: cannot verify chain ID: %w", err)
+	}
+	if nodeChainID.Cmp(c.chainID) != 0 {
+		return fmt.Errorf("init client: %w: network '%s' expects %s but node returned %s", ErrChainIDMismatch, c.network, c.chainID, nodeChainID)
+	}
+	c.chainID = nodeChainID
+	c.chainVerified = true
+	return nil
+}
+
+// ChainID returns the chain ID used by the client for signing. It is the one
+// served by the node when the client was built WithChainIDVerification, and
+// the one registered for the network otherwise.
+func (c *Client) ChainID() *big.Int {
+	return new(big.Int).Set(c.chainID)
+}
+
+// ChainIDVerified reports whether ChainID was verified against the node.
+func (c *Client) ChainIDVerified() bool {
+	return c.chainVerified
+}
+
 func (c *Client) CurrentNetwork() Network {
 	return c.network
 }
@@ -171,65 +253,70 @@ func (c *Client) URL() string {
 	return c.rocksideURL.String()
 }
 
-func (c *Client) get(urlPath string, body interface{}, decode interface{}) (*http.Response, error) {
-	return c.performRequest(http.MethodGet, urlPath, body, decode)
+func (c *Client) get(ctx context.Context, operation, urlPath string, body interface{}, decode interface{}) (*http.Response, error) {
+	return c.performRequest(withOperation(ctx, operation), http.MethodGet, urlPath, body, decode)
 }
 
-func (c *Client) post(urlPath string, body interface{}, decode interface{}) (*http.Response, error) {
-	return c.performRequest(http.MethodPost, urlPath, body, decode)
+func (c *Client) post(ctx context.Context, operation, urlPath string, body interface{}, decode interface{}) (*http.Response, error) {
+	return c.performRequest(withOperation(ctx, operation), http.MethodPost, urlPath, body, decode)
 }
 
-func (c *Client) delete(urlPath string, body interface{}, decode interface{}) (*http.Response, error) {
-	return c.performRequest(http.MethodDelete, urlPath, body, decode)
+func (c *Client) delete(ctx context.Context, operation, urlPath string, body interface{}, decode interface{}) (*http.Response, error) {
+	return c.performRequest(withOperation(ctx, operation), http.MethodDelete, urlPath, body, decode)
 }
 
-func (c *Client) put(urlPath string, body interface{}, decode interface{}) (*http.Response, error) {
-	return c.performRequest(http.MethodPut, urlPath, body, decode)
+func (c *Client) put(ctx context.Context, operation, urlPath string, body interface{}, decode interface{}) (*http.Response, error) {
+	return c.performRequest(withOperation(ctx, operation), http.MethodPut, urlPath, body, decode)
 }
 
-func (c *Client) performRequest(method, urlPath string, body interface{}, decode interface{}) (*http.Response, error) {
+func (c *Client) performRequest(ctx context.Context, method, urlPath string, body interface{}, decode interface{}) (*http.Response, error) {
 	path, err := url.Parse(urlPath)
 	if err != nil {
 		return nil, err
 	}
 	fullURL := c.rocksideURL.ResolveReference(path)
 
-	var buf io.ReadWriter
+	var payload []byte
 	if body != nil {
-		buf = new(bytes.Buffer)
+		buf := new(bytes.Buffer)
 		if err := json.NewEncoder(buf).Encode(body); err != nil {
 			return nil, err
 		}
+		payload = buf.Bytes()
 	}
 
-	req, err := http.NewRequest(method, fullURL.String(), buf)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", "rockside-sdk-go")
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-
-	dump, _ := httputil.DumpRequestOut(req, true)
-	c.logger.Printf(">>>>>> Request %s-----\n\n", dump)
+	retryAllowed := isRetryAllowed(ctx, method)
 
-	resp, err := c.authHTTPClient.Do(req)
+	var resp *http.Response
+	for attempt := 1; ; attempt++ {
+		resp, err = c.doRequest(ctx, method, fullURL.String(), payload)
+		if !retryAllowed || attempt >= c.retry.MaxAttempts {
+			break
+		}
+		wait, retry := c.retry.retryDelay(attempt, resp, err)
+		if !retry {
+			break
+		}
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		c.logger.Log(LevelWarn, "retrying request", "method", method, "path", fullURL.Path, "network", c.network, "attempt", attempt, "wait", wait)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	dump, _ = httputil.DumpResponse(resp, true)
-	c.logger.Printf("<<<<<< Response %s\n-----\n\n", dump)
-
 	if status := resp.StatusCode; status > 299 || status < 200 {
-		context := c.errorContextString(resp)
-		if msg, err := decodeJSONErr(resp.Body); err != nil {
-			return resp, fmt.Errorf("non JSON body returned (try verbose mode) %s: %s", context, err)
-		} else {
-			return resp, fmt.Errorf("%s %s", msg, context)
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return resp, err
 		}
+		return resp, newAPIError(resp, b, c.network)
 	}
 
 	if decode != nil {
@@ -241,29 +328,45 @@ func (c *Client) performRequest(method, urlPath string, body interface{}, decode
 	return resp, nil
 }
 
-func (c *Client) errorContextString(resp *http.Response) string {
-	return fmt.Sprintf("[status: %s, URL: '%s', network: %s, request ID: %s]", resp.Status, c.URL(), c.CurrentNetwork(), resp.Header.Get("X-Request-ID"))
+func (c *Client) doRequest(ctx context.Context, method, fullURL string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key := idempotencyKeyFromContext(ctx); key != "" {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+
+	return c.authHTTPClient.Do(req)
 }
 
-func decodeJSONErr(body io.Reader) (string, error) {
+func decodeJSONErr(body []byte) (string, string, error) {
 	v := struct {
 		Err     string `json:"error"`
 		Message string `json:"message"`
 	}{}
-	if err := json.NewDecoder(body).Decode(&v); err != nil {
-		return "", err
-	}
-	if v.Err != "" {
-		return v.Err, nil
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "", "", err
 	}
-	return v.Message, nil
+	return v.Err, v.Message, nil
 }
 
 type authenticatedHeaderTransport struct {
-	authFunc func(req *http.Request)
+	auth Auth
+	base http.RoundTripper
 }
 
 func (t *authenticatedHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	t.authFunc(req)
-	return http.DefaultTransport.RoundTrip(req)
+	req = req.Clone(req.Context())
+	t.auth.Authenticate(req)
+	return t.base.RoundTrip(req)
 }
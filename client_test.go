@@ -0,0 +1,121 @@
+package rockside
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := newClient(srv.Client(), Testnet, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetRetryPolicy(NoRetry)
+	return c
+}
+
+func TestNewWithOptions(t *testing.T) {
+	apiKey := strings.Repeat("k", 32)
+
+	var gotAPIKey, gotUserAgent string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAPIKey = r.Header.Get("apikey")
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	c, err := New(APIKey(apiKey), Testnet, WithBaseURL(srv.URL), WithTransport(srv.Client().Transport), WithUserAgent("myapp/1.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.EOA.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := gotAPIKey, apiKey; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := gotUserAgent, "rockside-sdk-go myapp/1.0"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := New(APIKey("short"), Testnet); err == nil {
+		t.Fatal("expected error for invalid API key, got none")
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		status      int
+		body        string
+		class       error
+		errContains string
+	}{
+		{status: 401, body: `{"error":"invalid api key"}`, class: ErrUnauthorized, errContains: "invalid api key"},
+		{status: 429, body: `{"message":"slow down"}`, class: ErrRateLimited, errContains: "slow down"},
+		{status: 404, body: `{"error":"no such transaction"}`, class: ErrNotFound, errContains: "no such transaction"},
+		{status: 400, body: `{"error":"invalid address"}`, class: ErrValidation, errContains: "invalid address"},
+		{status: 502, body: `<html>bad gateway</html>`, class: ErrServerError, errContains: "non JSON body returned"},
+	}
+
+	for i, test := range tests {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-ID", "req-42")
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		})
+
+		_, err := c.EOA.List()
+		if err == nil {
+			t.Fatalf("case %d: expected error, got none", i+1)
+		}
+		if !errors.Is(err, test.class) {
+			t.Fatalf("case %d: expected error %q to be %q", i+1, err, test.class)
+		}
+		if !strings.Contains(err.Error(), test.errContains) {
+			t.Fatalf("case %d: expecting error %q to contains %q", i+1, err, test.errContains)
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("case %d: expected *APIError, got %T", i+1, err)
+		}
+		if got, want := apiErr.StatusCode, test.status; got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+		if got, want := apiErr.RequestID, "req-42"; got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+		if got, want := string(apiErr.Body), test.body; got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+		if got, want := apiErr.Network, Testnet; got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestRPCErrorClassification(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"invalid params"}}`))
+	})
+
+	_, err := c.RPCClient.EthAccounts()
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("expected *RPCError, got %T (%v)", err, err)
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected error %q to be %q", err, ErrValidation)
+	}
+	if got, want := rpcErr.Code, -32602; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -3,8 +3,10 @@ package main
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocksideio/rockside-sdk-go"
 
 	"github.com/spf13/cobra"
@@ -177,8 +179,15 @@ var (
 				return err
 			}
 
-			signResponse, err := RocksideClient().Forwarder.SignTxParams(privateKeyFlag, contractAddress, tx.From, tx.To, tx.Data, tx.Nonce)
+			signer, err := forwarderSigner()
+			if err != nil {
+				return err
+			}
+			if tx.From != "" && common.HexToAddress(tx.From) != signer.Address() {
+				return fmt.Errorf("'from' address %s is not the address of the signing key %s", tx.From, signer.Address().String())
+			}
 
+			signResponse, err := RocksideClient().Forwarder.SignTxParamsWithSigner(signer, contractAddress, tx.To, tx.Data, tx.Nonce)
 			if err != nil {
 				return err
 			}
@@ -187,6 +196,29 @@ var (
 		},
 	}
 
+	verifyRelayCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "verify locally the signature and nonce of a transaction to be relayed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("missing contract address and transaction payload {\"message\":{\"signer\":\"\", \"to\":\"\", \"data\":\"\", \"nonce\":\"\"}, \"signature\":\"\"}")
+			}
+
+			contractAddress := args[0]
+			txJSON := args[1]
+			relayTx := &rockside.RelayExecuteTxRequest{}
+			if err := json.Unmarshal([]byte(txJSON), relayTx); err != nil {
+				return err
+			}
+
+			if err := RocksideClient().Forwarder.VerifyRelayRequest(contractAddress, *relayTx); err != nil {
+				return err
+			}
+
+			return printJSON(map[string]interface{}{"valid": true, "signer": relayTx.Message.Signer, "nonce": relayTx.Message.Nonce})
+		},
+	}
+
 	relayCmd = &cobra.Command{
 		Use:   "relay",
 		Short: "relay transaction",
@@ -223,7 +255,7 @@ var (
 		Short: "send transaction",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
-				return errors.New("missing transaction payload {\"from\":\"\",\"to\":\"\", \"value\":\"\", gas:\"\", \"gasPrice\":\"\", \"nonce\":\"\"}")
+				return errors.New("missing transaction payload {\"from\":\"\",\"to\":\"\", \"value\":\"\", \"data\":\"\", \"gas\":\"\", \"gasprice\":\"\", \"nonce\":\"\"}")
 			}
 
 			txJSON := args[0]
@@ -253,7 +285,23 @@ var (
 				return err
 			}
 
-			return printJSON(result)
+			return printJSON(result.Raw)
+		},
+	}
+)
+
+var (
+	networksCmd = &cobra.Command{
+		Use:   "networks",
+		Short: "List networks known to the client",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var configs []rockside.NetworkConfig
+			for _, n := range rockside.RegisteredNetworks() {
+				cfg, _ := rockside.LookupNetwork(n)
+				configs = append(configs, cfg)
+			}
+
+			return printJSON(configs)
 		},
 	}
 )
@@ -108,7 +108,11 @@ var (
 				return fmt.Errorf("cannot deploy contract: %s (txhash=%s)", err, tx)
 			}
 
-			log.Printf("successfully deployed contract with receipt %s/tx/%s", RocksideClient().CurrentNetwork().ExplorerURL(), tx)
+			if explorerURL := RocksideClient().CurrentNetwork().ExplorerTxURL(tx); explorerURL != "" {
+				log.Printf("successfully deployed contract with receipt %s", explorerURL)
+			} else {
+				log.Printf("successfully deployed contract with transaction %s", tx)
+			}
 
 			return nil
 		},
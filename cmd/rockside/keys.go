@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+func defaultKeystoreDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "keystore"
+	}
+	return filepath.Join(home, ".rockside", "keystore")
+}
+
+type keyJSON struct {
+	Address string `json:"address"`
+	File    string `json:"file"`
+}
+
+var (
+	keysCmd = &cobra.Command{
+		Use:   "keys",
+		Short: "Manage the keys of the local encrypted keystore",
+	}
+
+	generateKeyCmd = &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a new key",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passphrase, err := readPassphrase(true)
+			if err != nil {
+				return err
+			}
+
+			account, err := localKeystore().NewAccount(passphrase)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(keyJSON{Address: account.Address.String(), File: account.URL.Path})
+		},
+	}
+
+	importKeyCmd = &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import a hex encoded private key read from a file, or prompted when no file is given",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var hexKey string
+			if len(args) > 0 {
+				b, err := ioutil.ReadFile(args[0])
+				if err != nil {
+					return err
+				}
+				hexKey = string(b)
+			} else {
+				k, err := promptSecret("Private key: ")
+				if err != nil {
+					return err
+				}
+				hexKey = k
+			}
+			key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+			if err != nil {
+				return fmt.Errorf("invalid private key: %w", err)
+			}
+
+			passphrase, err := readPassphrase(true)
+			if err != nil {
+				return err
+			}
+
+			account, err := localKeystore().ImportECDSA(key, passphrase)
+			if err != nil {
+				return err
+			}
+
+			return printJSON(keyJSON{Address: account.Address.String(), File: account.URL.Path})
+		},
+	}
+
+	listKeysCmd = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys := []keyJSON{}
+			for _, account := range localKeystore().Accounts() {
+				keys = append(keys, keyJSON{Address: account.Address.String(), File: account.URL.Path})
+			}
+
+			return printJSON(keys)
+		},
+	}
+
+	exportKeyCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export the encrypted JSON key of the given address",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("missing key address")
+			}
+			if !common.IsHexAddress(args[0]) {
+				return fmt.Errorf("invalid address %s", args[0])
+			}
+
+			ks := localKeystore()
+			account, err := ks.Find(accounts.Account{Address: common.HexToAddress(args[0])})
+			if err != nil {
+				return err
+			}
+			passphrase, err := readPassphrase(false)
+			if err != nil {
+				return err
+			}
+			encrypted, err := ks.Export(account, passphrase, passphrase)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(os.Stdout, string(encrypted))
+			return err
+		},
+	}
+)
+
+func localKeystore() *keystore.KeyStore {
+	return keystore.NewKeyStore(keystoreDirFlag, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+// forwarderSigner returns the signer given by --keystore or --privatekey.
+func forwarderSigner() (rockside.Signer, error) {
+	switch {
+	case keystoreFlag != "" && privateKeyFlag != "":
+		return nil, errors.New("--keystore and --privatekey are exclusive")
+	case keystoreFlag != "":
+		passphrase, err := readPassphrase(false)
+		if err != nil {
+			return nil, err
+		}
+		return rockside.NewKeystoreSigner(keystoreFlag, passphrase)
+	case privateKeyFlag != "":
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyFlag, "0x"))
+		if err != nil {
+			return nil, err
+		}
+		return rockside.NewPrivateKeySigner(key), nil
+	default:
+		return nil, errors.New("missing --keystore or --privatekey")
+	}
+}
+
+// readPassphrase reads the first line of --password-file, or prompts for
+// the passphrase (twice when confirm is set).
+func readPassphrase(confirm bool) (string, error) {
+	if passwordFileFlag != "" {
+		f, err := os.Open(passwordFileFlag)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		line, err := bufio.NewReader(f).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("cannot read passphrase from %s: %w", passwordFileFlag, err)
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	passphrase, err := promptSecret("Passphrase: ")
+	if err != nil {
+		return "", fmt.Errorf("%w, use --password-file", err)
+	}
+	if confirm {
+		again, err := promptSecret("Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
+func promptSecret(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return "", errors.New("cannot prompt without a terminal")
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	b, err := terminal.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 
@@ -13,8 +14,10 @@ var (
 	envRocksideTokenOrigin = os.Getenv("ROCKSIDE_TOKEN_ORIGIN")
 	envRocksideAPIURL      = os.Getenv("ROCKSIDE_API_URL")
 
-	rocksideTokenOrigin, rocksideURLFlag                  string
+	rocksideTokenOrigin, rocksideURLFlag, networkFlag     string
 	privateKeyFlag, smartWalletToDeployContractFlag       string
+	keystoreFlag, keystoreDirFlag, passwordFileFlag       string
+	receiptABIFlag                                        string
 	testnetFlag, verboseFlag                              bool
 	printContractABIFlag, printContractRuntimeBinFlag     bool
 	compileContractOnlyFlag, printContractCreationBinFlag bool
@@ -28,11 +31,17 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&rocksideURLFlag, "url", envRocksideAPIURL, "Rockside API URL")
 	rootCmd.PersistentFlags().StringVar(&rocksideTokenOrigin, "token-origin", envRocksideTokenOrigin, "Origin associated with token")
 	rootCmd.PersistentFlags().BoolVar(&testnetFlag, "testnet", false, "Use testnet (Ropsten) instead of mainnet")
+	rootCmd.PersistentFlags().StringVar(&networkFlag, "network", "", fmt.Sprintf("Network to use, one of %s (overrides --testnet)", rockside.RegisteredNetworks()))
 	rootCmd.PersistentFlags().BoolVar(&verboseFlag, "verbose", false, "Verbose Rockside client")
 
-	signCmd.PersistentFlags().StringVar(&privateKeyFlag, "privatekey", "", "privatekey")
-	signCmd.MarkPersistentFlagRequired("privatekey")
-	forwarderCmd.AddCommand(getNonceCmd, signCmd, relayCmd)
+	signCmd.PersistentFlags().StringVar(&privateKeyFlag, "privatekey", "", "Hex encoded private key of the signer (prefer --keystore)")
+	signCmd.PersistentFlags().StringVar(&keystoreFlag, "keystore", "", "Encrypted JSON key file of the signer")
+	signCmd.PersistentFlags().StringVar(&passwordFileFlag, "password-file", "", "File holding the passphrase of the key, prompted otherwise")
+	showReceiptCmd.Flags().StringVar(&receiptABIFlag, "abi", "", "Path to a contract ABI file declaring the custom errors to decode in revert reasons")
+	forwarderCmd.AddCommand(getNonceCmd, signCmd, verifyRelayCmd, relayCmd)
+	keysCmd.PersistentFlags().StringVar(&keystoreDirFlag, "keystore-dir", defaultKeystoreDir(), "Directory of the encrypted JSON keys")
+	keysCmd.PersistentFlags().StringVar(&passwordFileFlag, "password-file", "", "File holding the passphrase of the key, prompted otherwise")
+	keysCmd.AddCommand(generateKeyCmd, importKeyCmd, listKeysCmd, exportKeyCmd)
 	transactionCmd.AddCommand(sentTxCmd, showTxCmd)
 	eoaCmd.AddCommand(listEOACmd, createEOACmd)
 	smartWalletsCmd.AddCommand(listSmartWalletsCmd, createSmartWalletCmd)
@@ -44,7 +53,7 @@ func init() {
 	deployContractCmd.PersistentFlags().BoolVar(&printContractCreationBinFlag, "print-creation-bin", false, "Compile, print contract creation bytecode and exit")
 	deployContractCmd.PersistentFlags().BoolVar(&compileContractOnlyFlag, "compile-only", false, "Compile without deploying and exit")
 
-	rootCmd.AddCommand(eoaCmd, smartWalletsCmd, transactionCmd, deployContractCmd, rpcCmd, showReceiptCmd, tokensCmd)
+	rootCmd.AddCommand(eoaCmd, smartWalletsCmd, transactionCmd, deployContractCmd, rpcCmd, showReceiptCmd, tokensCmd, networksCmd, forwarderCmd, keysCmd)
 }
 
 func RocksideClient() *rockside.Client {
@@ -52,6 +61,9 @@ func RocksideClient() *rockside.Client {
 	if testnetFlag {
 		network = rockside.Testnet
 	}
+	if networkFlag != "" {
+		network = rockside.Network(networkFlag)
+	}
 
 	if envRocksideAPIKey != "" && envRocksideToken != "" {
 		log.Fatal("both ROCKSIDE_API_KEY and ROCKSIDE_TOKEN are defined as environment variables. Pick one!")
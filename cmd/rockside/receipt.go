@@ -4,15 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"log"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocksideio/rockside-sdk-go"
 	"github.com/spf13/cobra"
 )
 
 var (
 	showReceiptCmd = &cobra.Command{
 		Use:   "receipt",
-		Short: "Get the transaction receipt for the given transaction hash",
+		Short: "Get the transaction receipt for the given transaction hash, with the revert reason of failed transactions",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return errors.New("missing transaction hash")
@@ -20,12 +24,39 @@ var (
 
 			txhash := common.HexToHash(args[0])
 
-			receipt, err := RocksideClient().RPCClient.TransactionReceipt(context.Background(), txhash)
+			client := RocksideClient()
+			receipt, err := client.RPCClient.TransactionReceipt(context.Background(), txhash)
 			if err != nil {
 				return fmt.Errorf("with tx hash %s: %s", txhash.String(), err)
 			}
 
 			printJSON(receipt)
+
+			if receipt.Status == types.ReceiptStatusFailed {
+				var jsonABIs []string
+				if receiptABIFlag != "" {
+					b, err := ioutil.ReadFile(receiptABIFlag)
+					if err != nil {
+						return err
+					}
+					jsonABIs = append(jsonABIs, string(b))
+				}
+				decoder, err := rockside.NewRevertDecoder(jsonABIs...)
+				if err != nil {
+					return err
+				}
+
+				revert, err := client.RPCClient.RevertReason(context.Background(), txhash, decoder)
+				if err != nil {
+					log.Printf("transaction failed, cannot get revert reason: %s", err)
+					return nil
+				}
+				if revert.Reason == "" {
+					log.Printf("transaction failed without revert reason (revert data 0x%x)", revert.Data)
+					return nil
+				}
+				log.Printf("transaction failed with revert reason: %s", revert.Reason)
+			}
 			return nil
 		},
 	}
@@ -1,6 +1,7 @@
 package rockside
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -10,6 +11,10 @@ import (
 )
 
 func (c *Client) DeployContractWithSmartWallet(rocksideSmartWalletAddr, code, jsonABI string) (string, error) {
+	return c.DeployContractWithSmartWalletWithContext(context.Background(), rocksideSmartWalletAddr, code, jsonABI)
+}
+
+func (c *Client) DeployContractWithSmartWalletWithContext(ctx context.Context, rocksideSmartWalletAddr, code, jsonABI string) (string, error) {
 	if _, err := hexutil.Decode(rocksideSmartWalletAddr); err != nil {
 		return "", fmt.Errorf("invalid smart wallet address: %s", err)
 	}
@@ -26,7 +31,7 @@ func (c *Client) DeployContractWithSmartWallet(rocksideSmartWalletAddr, code, js
 
 	var data []byte
 	data = append(common.FromHex(code), input...)
-	resp, err := c.Transaction.Send(Transaction{
+	resp, err := c.Transaction.SendWithContext(ctx, Transaction{
 		From: rocksideSmartWalletAddr,
 		Data: fmt.Sprintf("0x%x", data),
 	})
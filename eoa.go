@@ -1,13 +1,20 @@
 package rockside
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type EOA endpoint
 
 func (e *EOA) Create() (addressResponse, error) {
+	return e.CreateWithContext(context.Background())
+}
+
+func (e *EOA) CreateWithContext(ctx context.Context) (addressResponse, error) {
 	var result addressResponse
 
-	if _, err := e.client.post("ethereum/eoa", nil, &result); err != nil {
+	if _, err := e.client.post(ctx, "EOA.Create", "ethereum/eoa", nil, &result); err != nil {
 		return result, err
 	}
 
@@ -15,9 +22,13 @@ func (e *EOA) Create() (addressResponse, error) {
 }
 
 func (e *EOA) List() ([]string, error) {
+	return e.ListWithContext(context.Background())
+}
+
+func (e *EOA) ListWithContext(ctx context.Context) ([]string, error) {
 	var result []string
 
-	if _, err := e.client.get("ethereum/eoa", nil, &result); err != nil {
+	if _, err := e.client.get(ctx, "EOA.List", "ethereum/eoa", nil, &result); err != nil {
 		return result, err
 	}
 
@@ -30,6 +41,10 @@ type SignTransactionRequest struct {
 }
 
 func (e *EOA) SignTransaction(address string, transaction SignTransactionRequest) (string, error) {
+	return e.SignTransactionWithContext(context.Background(), address, transaction)
+}
+
+func (e *EOA) SignTransactionWithContext(ctx context.Context, address string, transaction SignTransactionRequest) (string, error) {
 	path := fmt.Sprintf("ethereum/eoa/%s/sign", address)
 
 	type signedTxResult struct {
@@ -37,7 +52,7 @@ func (e *EOA) SignTransaction(address string, transaction SignTransactionRequest
 	}
 
 	var result signedTxResult
-	if _, err := e.client.post(path, transaction, &result); err != nil {
+	if _, err := e.client.post(ctx, "EOA.SignTransaction", path, transaction, &result); err != nil {
 		return "", err
 	}
 
@@ -49,6 +64,10 @@ type SignMessageRequest struct {
 }
 
 func (e *EOA) SignMessage(address string, message SignMessageRequest) (string, error) {
+	return e.SignMessageWithContext(context.Background(), address, message)
+}
+
+func (e *EOA) SignMessageWithContext(ctx context.Context, address string, message SignMessageRequest) (string, error) {
 	path := fmt.Sprintf("ethereum/eoa/%s/sign-message", address)
 
 	type signedTxResult struct {
@@ -56,7 +75,7 @@ func (e *EOA) SignMessage(address string, message SignMessageRequest) (string, e
 	}
 
 	var result signedTxResult
-	if _, err := e.client.post(path, message, &result); err != nil {
+	if _, err := e.client.post(ctx, "EOA.SignMessage", path, message, &result); err != nil {
 		return "", err
 	}
 
@@ -0,0 +1,135 @@
+package rockside
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Classification of errors returned by the Rockside API. Use them with
+// errors.Is on any error returned by the client:
+//
+//	if errors.Is(err, rockside.ErrRateLimited) { ... }
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrNotFound     = errors.New("not found")
+	ErrValidation   = errors.New("validation failed")
+	ErrServerError  = errors.New("server error")
+)
+
+// ErrTransactionFailed is returned when waiting for a transaction which ends
+// up mined with a failed status.
+var ErrTransactionFailed = errors.New("transaction failed")
+
+// ErrInvalidSignature and ErrNonceMismatch are returned by
+// Forwarder.VerifyRelayRequest for requests Rockside would refuse.
+var (
+	ErrInvalidSignature = errors.New("invalid relay signature")
+	ErrNonceMismatch    = errors.New("relay nonce mismatch")
+)
+
+// ErrChainIDMismatch is returned by New when the chain ID served by the node
+// differs from the chain ID of the network.
+var ErrChainIDMismatch = errors.New("chain ID mismatch")
+
+// APIError is returned when the Rockside API answers with a non 2xx status.
+type APIError struct {
+	StatusCode int
+	Status     string
+	RequestID  string
+	URL        string
+	Network    Network
+
+	// Body is the raw response body.
+	Body []byte
+
+	// Err and Message are decoded from the JSON body fields "error" and "message".
+	Err     string
+	Message string
+
+	decodeErr error
+}
+
+func newAPIError(resp *http.Response, body []byte, network Network) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+		Network:    network,
+		Body:       body,
+	}
+	if resp.Request != nil {
+		e.URL = resp.Request.URL.String()
+	}
+	e.Err, e.Message, e.decodeErr = decodeJSONErr(body)
+	return e
+}
+
+func (e *APIError) Error() string {
+	context := fmt.Sprintf("[status: %s, URL: '%s', network: %s, request ID: %s]", e.Status, e.URL, e.Network, e.RequestID)
+	if e.decodeErr != nil {
+		return fmt.Sprintf("non JSON body returned (try verbose mode) %s: %s", context, e.decodeErr)
+	}
+	msg := e.Err
+	if msg == "" {
+		msg = e.Message
+	}
+	return fmt.Sprintf("%s %s", msg, context)
+}
+
+// Is reports whether the error falls in the given classification
+// (ErrUnauthorized, ErrRateLimited, ErrNotFound, ErrValidation or ErrServerError).
+func (e *APIError) Is(target error) bool {
+	return target != nil && classifyHTTPStatus(e.StatusCode) == target
+}
+
+func classifyHTTPStatus(status int) error {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return ErrUnauthorized
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status == http.StatusNotFound:
+		return ErrNotFound
+	case status == http.StatusBadRequest, status == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case status >= 500:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
+
+// RPCError is a JSON-RPC error object returned by the Rockside RPC endpoint.
+type RPCError struct {
+	Endpoint string `json:"-"`
+	Code     int    `json:"code"`
+	Message  string `json:"message"`
+
+	// Data is the optional error data, such as the revert data of eth_call.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error: %s (code=%d, url=%s)", e.Message, e.Code, e.Endpoint)
+}
+
+// Is reports whether the error falls in the given classification, based on
+// the standard JSON-RPC 2.0 error codes.
+func (e *RPCError) Is(target error) bool {
+	if target == nil {
+		return false
+	}
+	switch e.Code {
+	case -32600, -32602:
+		return target == ErrValidation
+	case -32601:
+		return target == ErrNotFound
+	case -32603:
+		return target == ErrServerError
+	default:
+		return false
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocksideio/rockside-sdk-go"
@@ -24,6 +25,24 @@ func ExampleNewClientFromAPIKey() {
 	fmt.Println(smartWallets)
 }
 
+func ExampleNew() {
+	rocksideAPIclient, err := rockside.New(
+		rockside.APIKey(os.Getenv("ROCKSIDE_API_KEY")),
+		rockside.Testnet,
+		rockside.WithTimeout(10*time.Second),
+		rockside.WithUserAgent("myapp/1.0"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	smartWallets, err := rocksideAPIclient.SmartWallets.List()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(smartWallets)
+}
+
 func ExampleNewClientFromToken() {
 	rocksideAPIclient, err := rockside.NewClientFromToken("token", "example.com", rockside.Testnet)
 	if err != nil {
@@ -37,6 +56,17 @@ func ExampleNewClientFromToken() {
 	fmt.Println(smartWallets)
 }
 
+func ExampleSmartWallets_ListWithContext() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	smartWallets, err := rocksideClient.SmartWallets.ListWithContext(ctx)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(smartWallets)
+}
+
 func ExampleRPCClient() {
 	// Get a RPC client from your existing Rockside client.
 	rpc := rocksideClient.RPCClient
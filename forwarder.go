@@ -1,6 +1,7 @@
 package rockside
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -11,6 +12,9 @@ import (
 	gethSigner "github.com/ethereum/go-ethereum/signer/core"
 )
 
+// ForwarderABI is the ABI of the forward function of the Rockside forwarder contract.
+const ForwarderABI = `[{"inputs":[{"internalType":"bytes","name":"signature","type":"bytes"},{"internalType":"address","name":"signer","type":"address"},{"internalType":"address","name":"to","type":"address"},{"internalType":"bytes","name":"data","type":"bytes"},{"internalType":"uint256","name":"nonce","type":"uint256"}],"name":"forward","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
 type Forwarder endpoint
 
 type RelayExecuteTxMessage struct {
@@ -21,7 +25,7 @@ type RelayExecuteTxMessage struct {
 }
 
 type RelayExecuteTxRequest struct {
-	Speed         string                `json:"speed"`
+	Speed         Speed                 `json:"speed"`
 	GasPriceLimit string                `json:"gas_price_limit"`
 	Message       RelayExecuteTxMessage `json:"message"`
 	Signature     string                `json:"signature"`
@@ -34,23 +38,40 @@ type RelayTxResponse struct {
 }
 
 func (e *Forwarder) Create(owner string) (ContractCreationResponse, error) {
+	return e.CreateWithContext(context.Background(), owner)
+}
+
+func (e *Forwarder) CreateWithContext(ctx context.Context, owner string) (ContractCreationResponse, error) {
 	req := struct {
 		Owner string `json:"owner"`
 	}{owner}
 
 	var result ContractCreationResponse
-	path := fmt.Sprintf("ethereum/%s/forwarders", e.client.network)
-	if _, err := e.client.post(path, req, &result); err != nil {
+	path := fmt.Sprintf("ethereum/%s/forwarders", e.client.networkPath)
+	if _, err := e.client.post(ctx, "Forwarder.Create", path, req, &result); err != nil {
 		return result, err
 	}
 
 	return result, nil
 }
 
+// CreateForSigner creates a forwarder owned by the signer address.
+func (e *Forwarder) CreateForSigner(signer Signer) (ContractCreationResponse, error) {
+	return e.CreateForSignerWithContext(context.Background(), signer)
+}
+
+func (e *Forwarder) CreateForSignerWithContext(ctx context.Context, signer Signer) (ContractCreationResponse, error) {
+	return e.CreateWithContext(ctx, signer.Address().String())
+}
+
 func (e *Forwarder) Get() ([]string, error) {
+	return e.GetWithContext(context.Background())
+}
+
+func (e *Forwarder) GetWithContext(ctx context.Context) ([]string, error) {
 	var result []string
-	path := fmt.Sprintf("ethereum/%s/forwarders", e.client.network)
-	if _, err := e.client.get(path, nil, &result); err != nil {
+	path := fmt.Sprintf("ethereum/%s/forwarders", e.client.networkPath)
+	if _, err := e.client.get(ctx, "Forwarder.Get", path, nil, &result); err != nil {
 		return result, err
 	}
 
@@ -58,18 +79,23 @@ func (e *Forwarder) Get() ([]string, error) {
 }
 
 func (e *Forwarder) GetRelayParams(forwarderAddress string, account string, channels ...string) (paramsResponse, error) {
+	return e.GetRelayParamsWithContext(context.Background(), forwarderAddress, account, channels...)
+}
+
+func (e *Forwarder) GetRelayParamsWithContext(ctx context.Context, forwarderAddress string, account string, channels ...string) (paramsResponse, error) {
 	channel := "0"
 	if len(channels) > 0 {
 		channel = channels[0]
 	}
 	var result paramsResponse
 
-	path := fmt.Sprintf("ethereum/%s/forwarders/%s/relayParams", e.client.network, forwarderAddress)
+	path := fmt.Sprintf("ethereum/%s/forwarders/%s/relayParams", e.client.networkPath, forwarderAddress)
 	req := struct {
 		Account   string `json:"account"`
 		ChannelID string `json:"channel_id"`
 	}{Account: account, ChannelID: channel}
-	_, err := e.client.post(path, req, &result)
+	// Reading the relay params sends a POST but does not change any state.
+	_, err := e.client.post(WithRetrySafe(ctx), "Forwarder.GetRelayParams", path, req, &result)
 	if err != nil {
 		return result, err
 	}
@@ -78,28 +104,60 @@ func (e *Forwarder) GetRelayParams(forwarderAddress string, account string, chan
 }
 
 func (e *Forwarder) Relay(forwarderAddress string, request RelayExecuteTxRequest) (RelayTxResponse, error) {
+	return e.RelayWithContext(context.Background(), forwarderAddress, request)
+}
+
+func (e *Forwarder) RelayWithContext(ctx context.Context, forwarderAddress string, request RelayExecuteTxRequest) (RelayTxResponse, error) {
 	var result RelayTxResponse
 
 	if request.Speed == "" {
-		request.Speed = "standard"
+		request.Speed = SpeedStandard
+	}
+	if err := request.Speed.Validate(); err != nil {
+		return result, err
 	}
 
-	path := fmt.Sprintf("ethereum/%s/forwarders/%s", e.client.network, forwarderAddress)
-	if _, err := e.client.post(path, request, &result); err != nil {
+	if e.client.simulate {
+		if _, err := e.SimulateRelayWithContext(ctx, forwarderAddress, request); err != nil {
+			return result, fmt.Errorf("simulate relay: %w", err)
+		}
+	}
+
+	path := fmt.Sprintf("ethereum/%s/forwarders/%s", e.client.networkPath, forwarderAddress)
+	if _, err := e.client.post(ctx, "Forwarder.Relay", path, request, &result); err != nil {
 		return result, err
 	}
 
 	return result, nil
 }
 
+// SignTxParams signs the relay parameters with the hex encoded private key.
+// Prefer SignTxParamsWithSigner, which does not need the key as a string.
 func (e *Forwarder) SignTxParams(privateKeyStr, forwarder, signer, destination, data, nonce string) (string, error) {
+	return e.SignTxParamsWithContext(context.Background(), privateKeyStr, forwarder, signer, destination, data, nonce)
+}
+
+func (e *Forwarder) SignTxParamsWithContext(ctx context.Context, privateKeyStr, forwarder, signer, destination, data, nonce string) (string, error) {
 	privateKey, err := crypto.HexToECDSA(privateKeyStr)
 	if err != nil {
 		return "", err
 	}
+	return e.signTxParams(ctx, NewPrivateKeySigner(privateKey), common.HexToAddress(signer), forwarder, destination, data, nonce)
+}
+
+// SignTxParamsWithSigner signs the relay parameters of a meta-transaction
+// from the signer address. When nonce is empty, it is fetched with GetRelayParams.
+func (e *Forwarder) SignTxParamsWithSigner(signer Signer, forwarder, destination, data, nonce string) (string, error) {
+	return e.SignTxParamsWithSignerWithContext(context.Background(), signer, forwarder, destination, data, nonce)
+}
 
+func (e *Forwarder) SignTxParamsWithSignerWithContext(ctx context.Context, signer Signer, forwarder, destination, data, nonce string) (string, error) {
+	return e.signTxParams(ctx, signer, signer.Address(), forwarder, destination, data, nonce)
+}
+
+func (e *Forwarder) signTxParams(ctx context.Context, s Signer, signer common.Address, forwarder, destination, data, nonce string) (string, error) {
 	if nonce == "" {
-		paramsResponse, err := e.GetRelayParams(forwarder, signer)
+		paramsResponse, err := e.GetRelayParamsWithContext(ctx, forwarder, signer.String())
 		if err != nil {
 			return "", err
 		}
@@ -110,14 +168,12 @@ func (e *Forwarder) SignTxParams(privateKeyStr, forwarder, signer, destination,
 		return "", fmt.Errorf("nonce is not a valid number [%s]", nonce)
 	}
 
-	network := e.client.CurrentNetwork()
-
-	argsHash, err := GetHash(common.HexToAddress(signer), common.HexToAddress(destination), common.FromHex(data), nonceBig, common.HexToAddress(forwarder), network.ChainID())
+	argsHash, err := GetHash(signer, common.HexToAddress(destination), common.FromHex(data), nonceBig, common.HexToAddress(forwarder), e.client.chainID)
 	if err != nil {
 		return "", err
 	}
 
-	signedHash, err := crypto.Sign(argsHash, privateKey)
+	signedHash, err := s.SignHash(ctx, argsHash)
 	if err != nil {
 		return "", err
 	}
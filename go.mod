@@ -6,4 +6,6 @@ require (
 	github.com/btcsuite/btcd v0.0.0-20190109040709-5bda5314ca95 // indirect
 	github.com/ethereum/go-ethereum v1.9.9
 	github.com/spf13/cobra v0.0.5
+	github.com/tyler-smith/go-bip39 v1.0.1-0.20181017060643-dbb3b84ba2ef
+	golang.org/x/crypto v0.0.0-20190308221718-c2843e01d9a2
 )
@@ -0,0 +1,154 @@
+package rockside
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultHDBasePath is the BIP-44 path of Ethereum accounts. User indexes
+// are appended to it: the signer of index i is derived at m/44'/60'/0'/0/i.
+const DefaultHDBasePath = "m/44'/60'/0'/0"
+
+// SignerProvider returns the signer of the end user with the given index.
+type SignerProvider interface {
+	Signer(index uint32) (Signer, error)
+}
+
+// NewMnemonic returns a new random 24 words BIP-39 mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// HDWallet is a SignerProvider deriving keys from a BIP-39 mnemonic along
+// BIP-44 paths, so that the owners of end user forwarders and smart wallets
+// can be regenerated from the mnemonic alone.
+//
+//	wallet, err := rockside.NewHDWallet(mnemonic, "")
+//	signer, err := wallet.Signer(userIndex)
+//	forwarder, err := client.Forwarder.CreateForSigner(signer)
+type HDWallet struct {
+	master   extendedKey
+	basePath accounts.DerivationPath
+}
+
+// NewHDWallet returns the wallet of the mnemonic, with an optional BIP-39
+// passphrase, deriving signers under DefaultHDBasePath.
+func NewHDWallet(mnemonic, passphrase string) (*HDWallet, error) {
+	return NewHDWalletWithBasePath(mnemonic, passphrase, DefaultHDBasePath)
+}
+
+// NewHDWalletWithBasePath is NewHDWallet with another base derivation path,
+// such as m/44'/60'/1'/0 to keep the users of an application apart.
+func NewHDWalletWithBasePath(mnemonic, passphrase, basePath string) (*HDWallet, error) {
+	path, err := accounts.ParseDerivationPath(basePath)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(strings.Join(strings.Fields(mnemonic), " "), passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	master, err := newMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	return &HDWallet{master: master, basePath: path}, nil
+}
+
+// Signer returns the signer of the end user with the given index. Indexes
+// from 2^31 would derive hardened keys and are rejected: use Derive for
+// hardened paths.
+func (w *HDWallet) Signer(index uint32) (Signer, error) {
+	if index >= hardenedKeyStart {
+		return nil, fmt.Errorf("invalid user index %d, must be lower than %d", index, uint32(hardenedKeyStart))
+	}
+	path := make(accounts.DerivationPath, len(w.basePath), len(w.basePath)+1)
+	copy(path, w.basePath)
+	return w.Derive(append(path, index))
+}
+
+// Derive returns the signer of the key at the given full derivation path.
+func (w *HDWallet) Derive(path accounts.DerivationPath) (Signer, error) {
+	key := w.master
+	for _, index := range path {
+		var err error
+		if key, err = key.child(index); err != nil {
+			return nil, fmt.Errorf("cannot derive %s: %w", path, err)
+		}
+	}
+	privateKey, err := crypto.ToECDSA(math.PaddedBigBytes(key.key, 32))
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKeySigner(privateKey), nil
+}
+
+const hardenedKeyStart = 0x80000000
+
+var errInvalidChildKey = errors.New("invalid child key, use another index")
+
+// extendedKey is a BIP-32 extended private key.
+type extendedKey struct {
+	key       *big.Int
+	chainCode []byte
+}
+
+func newMasterKey(seed []byte) (extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := new(big.Int).SetBytes(sum[:32])
+	if key.Sign() == 0 || key.Cmp(crypto.S256().Params().N) >= 0 {
+		return extendedKey{}, errors.New("invalid master key, use another mnemonic")
+	}
+	return extendedKey{key: key, chainCode: sum[32:]}, nil
+}
+
+// child derives the private child key of the given index, hardened when
+// index >= 2^31.
+func (k extendedKey) child(index uint32) (extendedKey, error) {
+	var data []byte
+	if index >= hardenedKeyStart {
+		data = append([]byte{0}, math.PaddedBigBytes(k.key, 32)...)
+	} else {
+		privateKey, err := crypto.ToECDSA(math.PaddedBigBytes(k.key, 32))
+		if err != nil {
+			return extendedKey{}, err
+		}
+		data = crypto.CompressPubkey(&privateKey.PublicKey)
+	}
+	var i [4]byte
+	binary.BigEndian.PutUint32(i[:], index)
+	data = append(data, i[:]...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(n) >= 0 {
+		return extendedKey{}, errInvalidChildKey
+	}
+	key := tweak.Add(tweak, k.key)
+	key.Mod(key, n)
+	if key.Sign() == 0 {
+		return extendedKey{}, errInvalidChildKey
+	}
+	return extendedKey{key: key, chainCode: sum[32:]}, nil
+}
@@ -0,0 +1,113 @@
+package rockside_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestHDWallet(t *testing.T) {
+	wallet, err := rockside.NewHDWallet(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		index   uint32
+		address string
+	}{
+		{index: 0, address: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		{index: 1, address: "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"},
+	}
+	for i, test := range tests {
+		signer, err := wallet.Signer(test.index)
+		if err != nil {
+			t.Fatalf("case %d: %v", i+1, err)
+		}
+		if got, want := signer.Address(), common.HexToAddress(test.address); got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got.String(), want.String())
+		}
+	}
+
+	if _, err := wallet.Signer(0x80000000); err == nil {
+		t.Fatal("expected error for hardened index")
+	}
+
+	path, _ := accounts.ParseDerivationPath("m/44'/60'/0'/0/1")
+	derived, err := wallet.Derive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := derived.Address(), common.HexToAddress(tests[1].address); got != want {
+		t.Fatalf("got %v, want %v", got.String(), want.String())
+	}
+
+	withPassphrase, err := rockside.NewHDWallet(testMnemonic, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := withPassphrase.Signer(0)
+	if other.Address() == common.HexToAddress(tests[0].address) {
+		t.Fatal("expected passphrase to change the derived keys")
+	}
+
+	if _, err := rockside.NewHDWallet("abandon abandon abandon", ""); err == nil {
+		t.Fatal("expected error for invalid mnemonic")
+	}
+	if _, err := rockside.NewHDWalletWithBasePath(testMnemonic, "", "m/44'/60'/x"); err == nil {
+		t.Fatal("expected error for invalid base path")
+	}
+
+	mnemonic, err := rockside.NewMnemonic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rockside.NewHDWallet(mnemonic, ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHDWalletRelay(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	wallet, err := rockside.NewHDWallet(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var provider rockside.SignerProvider = wallet
+	signer, err := provider.Signer(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forwarder, err := client.Forwarder.CreateForSigner(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smartWallet, err := client.SmartWallets.CreateForSigner(signer, forwarder.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	regenerated, _ := wallet.Signer(42)
+	signature, err := client.Forwarder.SignTxParamsWithSignerWithContext(ctx, regenerated, forwarder.Address, smartWallet.Address, "0x", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Forwarder.Relay(forwarder.Address, rockside.RelayExecuteTxRequest{
+		Signature: signature,
+		Message:   rockside.RelayExecuteTxMessage{Signer: signer.Address().String(), To: smartWallet.Address, Data: "0x", Nonce: "0"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
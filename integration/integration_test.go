@@ -1,36 +1,116 @@
 package rockside_test
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
 )
 
 /*
-Launch the integration tests with: ROCKSIDE_API_URL=... ROCKSIDE_API_KEY=... go test -v
-(or using BLOCK_WAIT_TIME env variable for a specific block wait time)
+Launch the integration tests against Rockside with: ROCKSIDE_API_URL=... ROCKSIDE_API_KEY=... go test -v
+(or using BLOCK_WAIT_TIME env variable for a specific maximum wait time, in seconds, for transactions to be mined)
+
+Record the interactions with Rockside to testdata/rockside, without credentials, with:
+ROCKSIDE_RECORD=1 ROCKSIDE_API_URL=... ROCKSIDE_API_KEY=... go test -v
+
+Without ROCKSIDE_API_URL, the tests replay the fixtures of testdata/rockside. No such fixture is
+committed yet: recording against the Rockside API needs credentials and was left out of the
+record/replay work. The tests then replay the fixtures of testdata/fake instead, recorded against the
+in-process rocksidetest fake server. Those only check the requests made by the SDK against the fake
+server, not against the Rockside API.
+
+Record the fake server fixtures again with: ROCKSIDE_RECORD=1 go test -v
 */
 
+const (
+	defaultGnosisAddress = "0x4b7b1e0fb3fcd4a3b4d4d0c46c5a8e31c3a5d6a0"
+
+	// Fixed forwarder owner key, so that recorded signatures can be replayed.
+	forwarderOwnerKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+)
+
 var (
 	blockWaitTime int
 	rocksideURL   = os.Getenv("ROCKSIDE_API_URL")
+	record        = os.Getenv("ROCKSIDE_RECORD") != ""
+	gnosisAddress = os.Getenv("GNOSIS_ADDRESS")
 )
 
-func TestRockside(t *testing.T) {
-	client, err := rockside.NewClientFromAPIKey(os.Getenv("ROCKSIDE_API_KEY"), rockside.Testnet, rocksideURL)
+// newClient returns a client for the given test group. It runs against
+// Rockside when ROCKSIDE_API_URL is set, recording the interactions when
+// asked to. Otherwise it replays the group fixture recorded against Rockside,
+// or against the fake server when there is none, or records the latter when
+// asked to.
+func newClient(t *testing.T, group string) *rockside.Client {
+	t.Helper()
+
+	if rocksideURL != "" && !record {
+		client, err := rockside.NewClientFromAPIKey(os.Getenv("ROCKSIDE_API_KEY"), rockside.Testnet, rocksideURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	var (
+		mode    = rocksidetest.ModeReplay
+		path    = fixturePath("rockside", group)
+		baseURL = "https://api.rockside.io"
+		auth    = rockside.APIKey(rocksidetest.APIKey)
+		base    http.RoundTripper
+	)
+	switch {
+	case record && rocksideURL != "":
+		mode, baseURL, auth = rocksidetest.ModeRecord, rocksideURL, rockside.APIKey(os.Getenv("ROCKSIDE_API_KEY"))
+	case record:
+		srv := rocksidetest.NewServer()
+		srv.AutoMine = true
+		t.Cleanup(srv.Close)
+		mode, path, baseURL, base = rocksidetest.ModeRecord, fixturePath("fake", group), srv.URL, srv.Server.Client().Transport
+	default:
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			path = fixturePath("fake", group)
+		}
+		t.Logf("replaying %s", path)
+	}
+
+	cassette, err := rocksidetest.NewCassette(path, mode, base)
 	if err != nil {
 		t.Fatal(err)
 	}
+	t.Cleanup(func() {
+		if err := cassette.Save(); err != nil {
+			t.Error(err)
+		}
+	})
 
+	client, err := rockside.New(auth, rockside.Testnet, rockside.WithBaseURL(baseURL), rockside.WithTransport(cassette))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func fixturePath(dir, group string) string {
+	return filepath.Join("testdata", dir, strings.ToLower(strings.ReplaceAll(group, " ", "_"))+".json")
+}
+
+func TestRockside(t *testing.T) {
 	t.Run("SmartWallets", func(t *testing.T) {
 		t.Parallel()
+		client := newClient(t, "SmartWallets")
 
 		t.Run("create", func(t *testing.T) {
 			eoa, err := client.EOA.Create()
@@ -106,6 +186,7 @@ func TestRockside(t *testing.T) {
 
 	t.Run("Transaction", func(t *testing.T) {
 		t.Parallel()
+		client := newClient(t, "Transaction")
 
 		t.Run("Send transaction from smart wallet", func(t *testing.T) {
 			eoa, err := client.EOA.Create()
@@ -124,7 +205,7 @@ func TestRockside(t *testing.T) {
 			}
 
 			//Need to wait for contract deployment's transaction to be mined
-			time.Sleep(time.Duration(blockWaitTime) * time.Second)
+			waitMined(t, client, smartWallet.TrackingID)
 
 			tx := rockside.Transaction{From: smartWallet.Address, To: smartWallet.Address, Value: "0x0"}
 			txResponse, err := client.Transaction.Send(tx)
@@ -141,6 +222,7 @@ func TestRockside(t *testing.T) {
 
 	t.Run("EOA", func(t *testing.T) {
 		t.Parallel()
+		client := newClient(t, "EOA")
 
 		t.Run("create", func(t *testing.T) {
 			resp, err := client.EOA.Create()
@@ -192,17 +274,19 @@ func TestRockside(t *testing.T) {
 	})
 
 	t.Run("Direct Relay", func(t *testing.T) {
+		client := newClient(t, "Direct Relay")
+
 		t.Run("Get relay params", func(t *testing.T) {
-			resp, err := client.Relay.GetParams(os.Getenv("GNOSIS_ADDRESS"))
+			resp, err := client.Relay.GetParams(gnosisAddress)
 			if err != nil {
 				t.Fatal(err)
 			}
 
-			if got, want := (resp.Speeds["standard"].GasPrice == "0"), false; got != want {
+			if got, want := (resp.Speeds[rockside.SpeedStandard].GasPrice == "0"), false; got != want {
 				t.Fatalf("got %v, want %v", got, want)
 			}
 
-			if got, want := resp.Speeds["standard"].Relayer, "0x618E5C42ECdc63aD84c95D714aFAdA52602Bbac3"; got != want {
+			if got, want := resp.Speeds[rockside.SpeedStandard].Relayer, "0x618E5C42ECdc63aD84c95D714aFAdA52602Bbac3"; got != want {
 				t.Fatalf("got %v, want %v", got, want)
 			}
 
@@ -211,8 +295,9 @@ func TestRockside(t *testing.T) {
 
 	t.Run("Forwarder contract", func(t *testing.T) {
 		t.Parallel()
+		client := newClient(t, "Forwarder contract")
 
-		privateKey, err := crypto.GenerateKey()
+		privateKey, err := crypto.HexToECDSA(forwarderOwnerKey)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -254,7 +339,7 @@ func TestRockside(t *testing.T) {
 			}
 
 			//Need to wait for contract deployment's transaction to be mined
-			time.Sleep(time.Duration(blockWaitTime) * time.Second)
+			waitMined(t, client, smartWallet.TrackingID)
 
 			t.Run("Get relay params", func(t *testing.T) {
 				resp, err := client.Forwarder.GetRelayParams(forwarder.Address, fromAddress.String())
@@ -266,7 +351,7 @@ func TestRockside(t *testing.T) {
 					t.Fatalf("got %v, want %v", got, want)
 				}
 
-				if got, want := (resp.GasPrices["standard"] == "0"), false; got != want {
+				if got, want := (resp.GasPrices[rockside.SpeedStandard] == "0"), false; got != want {
 					t.Fatalf("got %v, want %v", got, want)
 				}
 			})
@@ -282,8 +367,8 @@ func TestRockside(t *testing.T) {
 				}
 
 				request := rockside.RelayExecuteTxRequest{
-					Speed:         "standard",
-					GasPriceLimit: params.GasPrices["standard"],
+					Speed:         rockside.SpeedStandard,
+					GasPriceLimit: params.GasPrices[rockside.SpeedStandard],
 					Signature:     signature,
 					Message: rockside.RelayExecuteTxMessage{
 						Signer: fromAddress.String(),
@@ -306,14 +391,25 @@ func TestRockside(t *testing.T) {
 	})
 }
 
+func waitMined(t *testing.T, client *rockside.Client, trackingID string) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(blockWaitTime)*time.Second)
+	defer cancel()
+
+	if _, err := client.Transaction.WaitMined(ctx, trackingID); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func exit(msg string) {
 	fmt.Fprintln(os.Stderr, msg)
 	os.Exit(1)
 }
 
 func init() {
-	if len(rocksideURL) == 0 {
-		exit("missing ROCKSIDE_API_URL env variable")
+	if len(gnosisAddress) == 0 {
+		gnosisAddress = defaultGnosisAddress
 	}
 
 	waitTime, exists := os.LookupEnv("BLOCK_WAIT_TIME")
@@ -328,5 +424,18 @@ func init() {
 
 	blockWaitTime = int
 
+	if len(rocksideURL) == 0 {
+		if record {
+			fmt.Fprintln(os.Stdout, "Recording fake server fixtures to testdata/fake")
+			return
+		}
+		fmt.Fprintln(os.Stdout, "Replaying fixtures from testdata/rockside, or fake server fixtures from testdata/fake when missing")
+		return
+	}
+	if record {
+		fmt.Fprintf(os.Stdout, "Recording fixtures to testdata/rockside against %s (block wait time %d)\n\n", rocksideURL, blockWaitTime)
+		return
+	}
+
 	fmt.Fprint(os.Stdout, fmt.Sprintf("Launching integration test on %s (block wait time %d)\n\n", rocksideURL, blockWaitTime))
 }
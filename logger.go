@@ -0,0 +1,174 @@
+package rockside
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+}
+
+// Logger receives the logs of the REST and JSON-RPC clients as a message
+// with alternating key/value pairs (method, path, status, duration,
+// request_id, network, rpc_method, ...).
+//
+// Request and response dumps, with credentials redacted, are logged at debug level.
+type Logger interface {
+	Enabled(level LogLevel) bool
+	Log(level LogLevel, msg string, keyvals ...interface{})
+}
+
+// StdLogger adapts a standard library logger, logging entries from the given level.
+func StdLogger(l *log.Logger, level LogLevel) Logger {
+	return &stdLogger{l: l, level: level}
+}
+
+type stdLogger struct {
+	l     *log.Logger
+	level LogLevel
+}
+
+func (s *stdLogger) Enabled(level LogLevel) bool {
+	return level >= s.level
+}
+
+func (s *stdLogger) Log(level LogLevel, msg string, keyvals ...interface{}) {
+	if !s.Enabled(level) {
+		return
+	}
+
+	var line, multiline strings.Builder
+	fmt.Fprintf(&line, "level=%s msg=%s", level, strconv.Quote(msg))
+	for i := 0; i < len(keyvals); i += 2 {
+		var v interface{} = "(MISSING)"
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		value := fmt.Sprint(v)
+		if strings.Contains(value, "\n") {
+			fmt.Fprintf(&multiline, "\n%s", value)
+			continue
+		}
+		if strings.ContainsAny(value, " \"=") {
+			value = strconv.Quote(value)
+		}
+		fmt.Fprintf(&line, " %v=%s", keyvals[i], value)
+	}
+
+	s.l.Print(line.String() + multiline.String())
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Enabled(LogLevel) bool                { return false }
+func (nopLogger) Log(LogLevel, string, ...interface{}) {}
+
+// loggingTransport logs every HTTP exchange of the REST and JSON-RPC clients.
+type loggingTransport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	logger := t.client.logger
+	if !logger.Enabled(LevelError) {
+		return t.base.RoundTrip(req)
+	}
+
+	req, body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []interface{}{"method", req.Method, "path", req.URL.Path, "network", t.client.network}
+	if op := operationFromContext(req.Context()); op != "" {
+		fields = append(fields, "operation", op)
+	}
+	if rpcMethod := jsonRPCMethod(body); rpcMethod != "" {
+		fields = append(fields, "rpc_method", rpcMethod)
+	}
+
+	if logger.Enabled(LevelDebug) {
+		logger.Log(LevelDebug, "request dump", append(fields, "dump", string(t.client.redactor.dumpRequest(req, body)))...)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields = append(fields, "duration", time.Since(start))
+	if err != nil {
+		logger.Log(LevelError, "request failed", append(fields, "error", err)...)
+		return nil, err
+	}
+
+	fields = append(fields, "status", resp.StatusCode, "request_id", resp.Header.Get("X-Request-ID"))
+	if logger.Enabled(LevelDebug) {
+		logger.Log(LevelDebug, "response dump", append(fields, "dump", string(t.client.redactor.dumpResponse(resp)))...)
+	}
+	level := LevelInfo
+	if resp.StatusCode > 299 || resp.StatusCode < 200 {
+		level = LevelWarn
+	}
+	logger.Log(level, "request done", fields...)
+
+	return resp, nil
+}
+
+// jsonRPCMethod returns the method(s) of a JSON-RPC request or batch body.
+func jsonRPCMethod(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+
+	type call struct {
+		Version string `json:"jsonrpc"`
+		Method  string `json:"method"`
+	}
+
+	if body[0] == '[' {
+		var batch []call
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return ""
+		}
+		var methods []string
+		for _, c := range batch {
+			if c.Version != "" {
+				methods = append(methods, c.Method)
+			}
+		}
+		return strings.Join(methods, ",")
+	}
+
+	var single call
+	if err := json.Unmarshal(body, &single); err != nil || single.Version == "" {
+		return ""
+	}
+	return single.Method
+}
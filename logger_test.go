@@ -0,0 +1,80 @@
+package rockside
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (l *recordingLogger) Enabled(LogLevel) bool { return true }
+
+func (l *recordingLogger) Log(level LogLevel, msg string, keyvals ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := fmt.Sprintf("%s %s", level, msg)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == "dump" || keyvals[i] == "duration" {
+			continue
+		}
+		entry += fmt.Sprintf(" %v=%v", keyvals[i], keyvals[i+1])
+	}
+	l.entries = append(l.entries, entry)
+}
+
+func (l *recordingLogger) contains(t *testing.T, want string) {
+	t.Helper()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range l.entries {
+		if strings.Contains(e, want) {
+			return
+		}
+	}
+	t.Fatalf("no log entry containing %q in:\n%s", want, strings.Join(l.entries, "\n"))
+}
+
+func TestStructuredLogging(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-1")
+		if strings.HasSuffix(r.URL.Path, "/jsonrpc") {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x3"}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+	logger := &recordingLogger{}
+	c.SetStructuredLogger(logger)
+
+	if _, err := c.EOA.List(); err != nil {
+		t.Fatal(err)
+	}
+	logger.contains(t, "info request done method=GET path=/ethereum/eoa network=ropsten operation=EOA.List status=200 request_id=req-1")
+	logger.contains(t, "debug request dump method=GET path=/ethereum/eoa")
+
+	if _, err := c.RPCClient.ChainID(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	logger.contains(t, "info request done method=POST path=/ethereum/ropsten/jsonrpc network=ropsten rpc_method=eth_chainId status=200")
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := StdLogger(log.New(&buf, "", 0), LevelInfo)
+
+	logger.Log(LevelDebug, "hidden")
+	logger.Log(LevelInfo, "request done", "method", "GET", "path", "/some path", "dump", "line1\nline2")
+
+	if got, want := buf.String(), "level=info msg=\"request done\" method=GET path=\"/some path\"\nline1\nline2\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package rockside
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// Call describes an HTTP call made by the client to Rockside.
+type Call struct {
+	// Operation names the call: the endpoint method for REST calls (e.g.
+	// "EOA.Create") and the JSON-RPC method for RPC calls (e.g. "eth_chainId").
+	// Batched JSON-RPC methods are comma separated.
+	Operation string
+
+	// RPC reports whether the call goes to the JSON-RPC endpoint.
+	RPC bool
+
+	// Request is the outgoing request. Interceptors may set headers on it.
+	Request *http.Request
+}
+
+// Invoker performs a call and returns its response.
+type Invoker func(call *Call) (*http.Response, error)
+
+// Interceptor is invoked for every HTTP call made by the client, REST and
+// JSON-RPC alike (including the calls of the embedded ethclient). It must
+// invoke next to perform the call, and sees its response or error.
+// Retried requests go through the interceptors on each attempt.
+type Interceptor func(call *Call, next Invoker) (*http.Response, error)
+
+// WithInterceptors adds interceptors to the client. They are invoked in the
+// given order, the first one being the outermost.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(o *options) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
+type operationContextKey struct{}
+
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, operation)
+}
+
+func operationFromContext(ctx context.Context) string {
+	op, _ := ctx.Value(operationContextKey{}).(string)
+	return op
+}
+
+type interceptorTransport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+func (t *interceptorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	interceptors := t.client.interceptors
+	if len(interceptors) == 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	req, body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	call := &Call{Operation: operationFromContext(req.Context()), Request: req.Clone(req.Context())}
+	if call.Operation == "" {
+		call.Operation = jsonRPCMethod(body)
+		call.RPC = call.Operation != ""
+	}
+
+	invoke := func(call *Call) (*http.Response, error) {
+		return t.base.RoundTrip(call.Request)
+	}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoke
+		invoke = func(call *Call) (*http.Response, error) {
+			return interceptor(call, next)
+		}
+	}
+
+	return invoke(call)
+}
+
+// readRequestBody reads the body of the request and returns a copy of the
+// request whose body can still be read.
+func readRequestBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return req, body, nil
+}
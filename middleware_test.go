@@ -0,0 +1,56 @@
+package rockside
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestInterceptors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Correlation-ID"), "corr-1"; got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if strings.HasSuffix(r.URL.Path, "/jsonrpc") {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x3"}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+
+	var calls []string
+	c.interceptors = []Interceptor{
+		func(call *Call, next Invoker) (*http.Response, error) {
+			call.Request.Header.Set("X-Correlation-ID", "corr-1")
+			return next(call)
+		},
+		func(call *Call, next Invoker) (*http.Response, error) {
+			resp, err := next(call)
+			if err != nil {
+				return nil, err
+			}
+			kind := "rest"
+			if call.RPC {
+				kind = "rpc"
+			}
+			calls = append(calls, kind+":"+call.Operation+":"+resp.Status)
+			return resp, nil
+		},
+	}
+
+	if _, err := c.SmartWallets.List(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RPCClient.ChainID(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RPCClient.EthAccounts(); err == nil {
+		t.Fatal("expected decoding error, got none")
+	}
+
+	want := []string{"rest:SmartWallets.List:200 OK", "rpc:eth_chainId:200 OK", "rpc:eth_accounts:200 OK"}
+	if got := strings.Join(calls, ","); got != strings.Join(want, ",") {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,157 @@
+package rockside
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+var (
+	Testnet           Network = "ropsten"
+	Mainnet           Network = "mainnet"
+	PoaNetworkMainnet Network = "poanetwork"
+	GethPrivateNet    Network = "gethprivate"
+)
+
+type Network string
+
+// NetworkConfig describes a network the client can be used with.
+type NetworkConfig struct {
+	Network Network  `json:"network"`
+	ChainID *big.Int `json:"chain_id"`
+
+	// PathSegment identifies the network in Rockside API paths
+	// (i.e. ethereum/<segment>/...). Defaults to the network name.
+	PathSegment string `json:"path_segment"`
+
+	ExplorerURL string `json:"explorer_url,omitempty"`
+
+	// Explorer page templates where %s is replaced with a transaction
+	// hash, an address or a token address.
+	ExplorerTxURL      string `json:"explorer_tx_url,omitempty"`
+	ExplorerAddressURL string `json:"explorer_address_url,omitempty"`
+	ExplorerTokenURL   string `json:"explorer_token_url,omitempty"`
+}
+
+var networks = struct {
+	sync.RWMutex
+	m map[Network]NetworkConfig
+}{m: make(map[Network]NetworkConfig)}
+
+func init() {
+	for _, cfg := range []NetworkConfig{
+		{
+			Network:            Mainnet,
+			ChainID:            big.NewInt(1),
+			ExplorerURL:        "https://etherscan.io",
+			ExplorerTxURL:      "https://etherscan.io/tx/%s",
+			ExplorerAddressURL: "https://etherscan.io/address/%s",
+			ExplorerTokenURL:   "https://etherscan.io/token/%s",
+		},
+		{
+			Network:            Testnet,
+			ChainID:            big.NewInt(3),
+			ExplorerURL:        "https://ropsten.etherscan.io",
+			ExplorerTxURL:      "https://ropsten.etherscan.io/tx/%s",
+			ExplorerAddressURL: "https://ropsten.etherscan.io/address/%s",
+			ExplorerTokenURL:   "https://ropsten.etherscan.io/token/%s",
+		},
+		{
+			Network:            PoaNetworkMainnet,
+			ChainID:            big.NewInt(99),
+			ExplorerURL:        "https://blockscout.com/poa/core",
+			ExplorerTxURL:      "https://blockscout.com/poa/core/tx/%s",
+			ExplorerAddressURL: "https://blockscout.com/poa/core/address/%s",
+			ExplorerTokenURL:   "https://blockscout.com/poa/core/tokens/%s",
+		},
+		{
+			Network: GethPrivateNet,
+			ChainID: big.NewInt(1337),
+		},
+	} {
+		if err := RegisterNetwork(cfg); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterNetwork makes a network available to clients, replacing any
+// network previously registered with the same name.
+func RegisterNetwork(cfg NetworkConfig) error {
+	if cfg.Network == "" {
+		return errors.New("register network: empty network name")
+	}
+	if cfg.ChainID == nil || cfg.ChainID.Sign() <= 0 {
+		return fmt.Errorf("register network '%s': invalid chain ID", cfg.Network)
+	}
+	if cfg.PathSegment == "" {
+		cfg.PathSegment = string(cfg.Network)
+	}
+	cfg.ChainID = new(big.Int).Set(cfg.ChainID)
+
+	networks.Lock()
+	defer networks.Unlock()
+	networks.m[cfg.Network] = cfg
+
+	return nil
+}
+
+// LookupNetwork returns the configuration of a registered network.
+func LookupNetwork(n Network) (NetworkConfig, bool) {
+	networks.RLock()
+	defer networks.RUnlock()
+	cfg, ok := networks.m[n]
+	if ok {
+		cfg.ChainID = new(big.Int).Set(cfg.ChainID)
+	}
+	return cfg, ok
+}
+
+// RegisteredNetworks returns the names of all registered networks, sorted.
+func RegisteredNetworks() []Network {
+	networks.RLock()
+	defer networks.RUnlock()
+	var all []Network
+	for n := range networks.m {
+		all = append(all, n)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	return all
+}
+
+func (n Network) ExplorerURL() string {
+	cfg, _ := LookupNetwork(n)
+	return cfg.ExplorerURL
+}
+
+func (n Network) ExplorerTxURL(txHash string) string {
+	cfg, _ := LookupNetwork(n)
+	return explorerPage(cfg.ExplorerTxURL, txHash)
+}
+
+func (n Network) ExplorerAddressURL(address string) string {
+	cfg, _ := LookupNetwork(n)
+	return explorerPage(cfg.ExplorerAddressURL, address)
+}
+
+func (n Network) ExplorerTokenURL(tokenAddress string) string {
+	cfg, _ := LookupNetwork(n)
+	return explorerPage(cfg.ExplorerTokenURL, tokenAddress)
+}
+
+func (n Network) ChainID() *big.Int {
+	cfg, ok := LookupNetwork(n)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return cfg.ChainID
+}
+
+func explorerPage(template, value string) string {
+	if template == "" {
+		return ""
+	}
+	return fmt.Sprintf(template, value)
+}
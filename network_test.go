@@ -0,0 +1,91 @@
+package rockside
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegisterNetwork(t *testing.T) {
+	custom := Network("custom")
+	if err := RegisterNetwork(NetworkConfig{
+		Network:       custom,
+		ChainID:       big.NewInt(4242),
+		PathSegment:   "custom-net",
+		ExplorerTxURL: "https://explorer.example.com/tx/%s",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := custom.ChainID().Int64(), int64(4242); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := custom.ExplorerTxURL("0x12"), "https://explorer.example.com/tx/0x12"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := custom.ExplorerAddressURL("0x34"), ""; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	var gotPath string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	c, err := newClient(srv.Client(), custom, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SmartWallets.List(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := gotPath, "/ethereum/custom-net/smartwallets"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := newClient(srv.Client(), Network("unknown"), srv.URL); err == nil {
+		t.Fatal("expected error for unregistered network, got none")
+	}
+	if err := RegisterNetwork(NetworkConfig{Network: "nochain"}); err == nil {
+		t.Fatal("expected error for missing chain ID, got none")
+	}
+}
+
+func TestChainIDVerification(t *testing.T) {
+	tests := []struct {
+		nodeChainID string
+		wantErr     error
+	}{
+		{nodeChainID: "0x3"},
+		{nodeChainID: "0x1", wantErr: ErrChainIDMismatch},
+	}
+
+	for i, test := range tests {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"` + test.nodeChainID + `"}`))
+		}))
+		defer srv.Close()
+
+		c, err := New(APIKey(strings.Repeat("k", 32)), Testnet, WithBaseURL(srv.URL), WithTransport(srv.Client().Transport), WithChainIDVerification())
+		if test.wantErr != nil {
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("case %d: expected error %q, got %v", i+1, test.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %d: unexpected error %s", i+1, err)
+		}
+		if got, want := c.ChainID().Int64(), int64(3); got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+		if !c.ChainIDVerified() {
+			t.Fatalf("case %d: expected chain ID to be verified", i+1)
+		}
+	}
+}
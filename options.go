@@ -0,0 +1,104 @@
+package rockside
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Client built with New.
+type Option func(*options)
+
+type options struct {
+	baseURL         string
+	transport       http.RoundTripper
+	timeout         time.Duration
+	userAgentSuffix string
+	logger          Logger
+	retry           *RetryPolicy
+	verifyChainID   bool
+	redactedHeaders []string
+	redactedFields  []string
+	interceptors    []Interceptor
+	simulate        bool
+}
+
+// WithBaseURL sets the Rockside API URL (default https://api.rockside.io).
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithTransport sets the underlying round tripper used to reach Rockside
+// (default http.DefaultTransport). Use it to configure proxies, custom CAs,
+// client certificates or connection pools.
+func WithTransport(t http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = t
+	}
+}
+
+// WithTimeout sets the timeout of each HTTP request sent to Rockside.
+// Retried attempts each get their own timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+// WithUserAgent appends the given suffix to the User-Agent sent to Rockside.
+func WithUserAgent(suffix string) Option {
+	return func(o *options) {
+		o.userAgentSuffix = suffix
+	}
+}
+
+// WithLogger sets the logger receiving the REST and JSON-RPC client logs.
+// Use StdLogger to adapt a standard library logger.
+func WithLogger(l Logger) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+// WithRedactedHeaders adds headers to redact from the request and response
+// dumps sent to the logger. Credential headers (apikey, Authorization, ...)
+// are always redacted.
+func WithRedactedHeaders(headers ...string) Option {
+	return func(o *options) {
+		o.redactedHeaders = append(o.redactedHeaders, headers...)
+	}
+}
+
+// WithRedactedFields adds JSON body fields (e.g. "signature") whose values
+// are redacted, at any depth, from the request and response dumps sent to
+// the logger. Fields "token" and "private_key" are always redacted.
+func WithRedactedFields(fields ...string) Option {
+	return func(o *options) {
+		o.redactedFields = append(o.redactedFields, fields...)
+	}
+}
+
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = &p
+	}
+}
+
+// WithChainIDVerification makes New query the chain ID served by the
+// Rockside RPC endpoint (eth_chainId) and fail if it differs from the chain ID
+// of the network. The verified chain ID is then used for signing.
+func WithChainIDVerification() Option {
+	return func(o *options) {
+		o.verifyChainID = true
+	}
+}
+
+// WithSimulation makes the client simulate transactions and relays with
+// eth_call before sending them, and return the simulation error, such as a
+// *RevertError, without sending them when the simulation fails.
+func WithSimulation() Option {
+	return func(o *options) {
+		o.simulate = true
+	}
+}
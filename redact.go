@@ -0,0 +1,127 @@
+package rockside
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	defaultRedactedHeaders = []string{"apikey", "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+	defaultRedactedFields  = []string{"token", "private_key"}
+)
+
+// redactor removes credentials from the request and response dumps sent to the logger.
+type redactor struct {
+	headers map[string]bool
+	fields  map[string]bool
+}
+
+func newRedactor() *redactor {
+	r := &redactor{headers: make(map[string]bool), fields: make(map[string]bool)}
+	r.addHeaders(defaultRedactedHeaders...)
+	r.addFields(defaultRedactedFields...)
+	return r
+}
+
+func (r *redactor) addHeaders(headers ...string) {
+	for _, h := range headers {
+		r.headers[http.CanonicalHeaderKey(h)] = true
+	}
+}
+
+func (r *redactor) addFields(fields ...string) {
+	for _, f := range fields {
+		r.fields[strings.ToLower(f)] = true
+	}
+}
+
+// dumpRequest dumps the request, given its body, with credentials redacted.
+func (r *redactor) dumpRequest(req *http.Request, body []byte) []byte {
+	clone := req.Clone(req.Context())
+	clone.Header = r.redactHeader(req.Header)
+
+	clone.Body, clone.ContentLength = nil, 0
+	if len(body) > 0 {
+		redactedBody := r.redactBody(body)
+		clone.Body = ioutil.NopCloser(bytes.NewReader(redactedBody))
+		clone.ContentLength = int64(len(redactedBody))
+	}
+
+	dump, _ := httputil.DumpRequestOut(clone, true)
+	return dump
+}
+
+// dumpResponse dumps the response with credentials redacted. The response
+// body is read and replaced so that it can still be consumed afterwards.
+func (r *redactor) dumpResponse(resp *http.Response) []byte {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+
+	redactedBody := r.redactBody(body)
+	clone := *resp
+	clone.Header = r.redactHeader(resp.Header)
+	clone.Body = ioutil.NopCloser(bytes.NewReader(redactedBody))
+	clone.ContentLength = int64(len(redactedBody))
+
+	dump, _ := httputil.DumpResponse(&clone, true)
+	return dump
+}
+
+func (r *redactor) redactHeader(h http.Header) http.Header {
+	clone := h.Clone()
+	for k := range clone {
+		if r.headers[http.CanonicalHeaderKey(k)] {
+			clone[k] = []string{redacted}
+		}
+	}
+	return clone
+}
+
+// redactBody replaces the values of the redacted fields found at any depth
+// of a JSON body. Non JSON bodies are returned as is.
+func (r *redactor) redactBody(body []byte) []byte {
+	if len(r.fields) == 0 || !json.Valid(body) {
+		return body
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+
+	b, err := json.Marshal(r.redactValue(v))
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+func (r *redactor) redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if r.fields[strings.ToLower(k)] {
+				val[k] = redacted
+			} else {
+				val[k] = r.redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range val {
+			val[i] = r.redactValue(item)
+		}
+	}
+	return v
+}
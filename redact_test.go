@@ -0,0 +1,102 @@
+package rockside
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedactedDumps(t *testing.T) {
+	auths := []Auth{
+		APIKey("secret-api-key-0123456789abcdefg"),
+		Token{Token: "secret-bearer-token", Origin: "https://secret-origin.example"},
+	}
+
+	for i, auth := range auths {
+		var gotAPIKey, gotAuthorization string
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotAPIKey, gotAuthorization = r.Header.Get("apikey"), r.Header.Get("Authorization")
+			w.Header().Set("Set-Cookie", "session=secret-cookie")
+			w.Write([]byte(`{"signed_message":"0xsigned","token":"secret-token"}`))
+		}))
+		defer srv.Close()
+
+		var logs bytes.Buffer
+		setSecretHeader := func(call *Call, next Invoker) (*http.Response, error) {
+			call.Request.Header.Set("X-Secret", "secret-interceptor-header")
+			return next(call)
+		}
+		c, err := New(auth, Testnet,
+			WithBaseURL(srv.URL),
+			WithTransport(srv.Client().Transport),
+			WithRetryPolicy(NoRetry),
+			WithLogger(StdLogger(log.New(&logs, "", 0), LevelDebug)),
+			WithInterceptors(setSecretHeader),
+			WithRedactedHeaders("X-Secret"),
+			WithRedactedFields("signature", "signed_message"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := struct {
+			Message   string `json:"message"`
+			Signature string `json:"signature"`
+			Nested    struct {
+				PrivateKey string `json:"private_key"`
+			} `json:"nested"`
+		}{Message: "hello", Signature: "0xsecret-signature"}
+		req.Nested.PrivateKey = "secret-private-key"
+
+		var result map[string]string
+		if _, err := c.post(context.Background(), "EOA.SignMessage", "ethereum/eoa/0x1/sign-message", req, &result); err != nil {
+			t.Fatal(err)
+		}
+
+		if gotAPIKey == "" && gotAuthorization == "" {
+			t.Fatalf("case %d: request not authenticated", i+1)
+		}
+		if got, want := result["token"], "secret-token"; got != want {
+			t.Fatalf("case %d: response body altered by dump: got %v, want %v", i+1, got, want)
+		}
+
+		dump := logs.String()
+		if !strings.Contains(dump, "request dump") || !strings.Contains(dump, "response dump") {
+			t.Fatalf("case %d: expected request and response dumps:\n%s", i+1, dump)
+		}
+		for _, secret := range []string{"secret-api-key", "secret-bearer-token", "secret-origin", "secret-interceptor-header", "secret-cookie", "secret-token", "0xsecret-signature", "secret-private-key", "0xsigned"} {
+			if strings.Contains(dump, secret) {
+				t.Fatalf("case %d: dump contains secret %q:\n%s", i+1, secret, dump)
+			}
+		}
+		if !strings.Contains(dump, `"message":"hello"`) {
+			t.Fatalf("case %d: expected dump to contain non redacted fields:\n%s", i+1, dump)
+		}
+	}
+}
+
+func TestRedactHeader(t *testing.T) {
+	r := newRedactor()
+	h := http.Header{}
+	h.Set("apikey", "secret")
+	h.Set("Authorization", "Bearer secret")
+	h.Set("Content-Type", "application/json")
+
+	redactedHeader := r.redactHeader(h)
+	if got, want := redactedHeader.Get("Apikey"), redacted; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := redactedHeader.Get("Authorization"), redacted; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := redactedHeader.Get("Content-Type"), "application/json"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := h.Get("Authorization"), "Bearer secret"; got != want {
+		t.Fatalf("original header modified: got %v, want %v", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package rockside
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -8,13 +9,17 @@ type Relay endpoint
 
 type RelayTx struct {
 	Data  string `json:"data"`
-	Speed string `json:"speed"`
+	Speed Speed  `json:"speed"`
 }
 
 func (e *Relay) GetParams(destination string) (RelayParamsResponse, error) {
+	return e.GetParamsWithContext(context.Background(), destination)
+}
+
+func (e *Relay) GetParamsWithContext(ctx context.Context, destination string) (RelayParamsResponse, error) {
 	var result RelayParamsResponse
-	path := fmt.Sprintf("ethereum/%s/relay/%s/params", e.client.network, destination)
-	_, err := e.client.get(path, nil, &result)
+	path := fmt.Sprintf("ethereum/%s/relay/%s/params", e.client.networkPath, destination)
+	_, err := e.client.get(ctx, "Relay.GetParams", path, nil, &result)
 	if err != nil {
 		return result, err
 	}
@@ -22,9 +27,26 @@ func (e *Relay) GetParams(destination string) (RelayParamsResponse, error) {
 }
 
 func (e *Relay) Relay(destination string, request RelayTx) (RelayTxResponse, error) {
+	return e.RelayWithContext(context.Background(), destination, request)
+}
+
+func (e *Relay) RelayWithContext(ctx context.Context, destination string, request RelayTx) (RelayTxResponse, error) {
 	var result RelayTxResponse
-	path := fmt.Sprintf("ethereum/%s/relay/%s", e.client.network, destination)
-	if _, err := e.client.post(path, request, &result); err != nil {
+
+	if request.Speed != "" {
+		if err := request.Speed.Validate(); err != nil {
+			return result, err
+		}
+	}
+
+	if e.client.simulate {
+		if _, err := e.SimulateWithContext(ctx, destination, request); err != nil {
+			return result, fmt.Errorf("simulate relay: %w", err)
+		}
+	}
+
+	path := fmt.Sprintf("ethereum/%s/relay/%s", e.client.networkPath, destination)
+	if _, err := e.client.post(ctx, "Relay.Relay", path, request, &result); err != nil {
 		return result, err
 	}
 	return result, nil
@@ -0,0 +1,90 @@
+package rockside
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// VerifyRelayRequest checks locally that the signature of the request
+// recovers to the message signer, and that the message nonce is the one
+// expected by the forwarder. It returns an error wrapping ErrInvalidSignature
+// or ErrNonceMismatch when Rockside would refuse to relay the request.
+func (e *Forwarder) VerifyRelayRequest(forwarderAddress string, request RelayExecuteTxRequest) error {
+	return e.VerifyRelayRequestWithContext(context.Background(), forwarderAddress, request)
+}
+
+func (e *Forwarder) VerifyRelayRequestWithContext(ctx context.Context, forwarderAddress string, request RelayExecuteTxRequest) error {
+	if !common.IsHexAddress(forwarderAddress) {
+		return fmt.Errorf("invalid forwarder address [%s]", forwarderAddress)
+	}
+	if err := VerifyRelaySignature(common.HexToAddress(forwarderAddress), e.client.chainID, request); err != nil {
+		return err
+	}
+
+	params, err := e.GetRelayParamsWithContext(ctx, forwarderAddress, request.Message.Signer)
+	if err != nil {
+		return err
+	}
+	expected, ok := new(big.Int).SetString(params.Nonce, 10)
+	if !ok {
+		return fmt.Errorf("invalid nonce returned by Rockside [%s]", params.Nonce)
+	}
+	nonce, _ := new(big.Int).SetString(request.Message.Nonce, 10)
+	if nonce.Cmp(expected) != 0 {
+		return fmt.Errorf("%w: message nonce is %s, forwarder expects %s", ErrNonceMismatch, nonce, expected)
+	}
+	return nil
+}
+
+// VerifyRelaySignature checks that the signature of the request recovers to
+// the message signer, with the EIP712 hash of the message for the forwarder
+// on the given chain. V may be either 0/1 or 27/28.
+func VerifyRelaySignature(forwarder common.Address, chainID *big.Int, request RelayExecuteTxRequest) error {
+	message := request.Message
+	if !common.IsHexAddress(message.Signer) {
+		return fmt.Errorf("invalid 'signer' address [%s]", message.Signer)
+	}
+	if !common.IsHexAddress(message.To) {
+		return fmt.Errorf("invalid 'to' address [%s]", message.To)
+	}
+	// Decoded as the signing path does, so that a request signed with
+	// SignTxParams verifies whether its data is 0x prefixed or not.
+	data := common.FromHex(message.Data)
+	nonce, ok := new(big.Int).SetString(message.Nonce, 10)
+	if !ok {
+		return fmt.Errorf("nonce is not a valid number [%s]", message.Nonce)
+	}
+
+	signature, err := hexutil.Decode(request.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: cannot decode signature: %v", ErrInvalidSignature, err)
+	}
+	if len(signature) != 65 {
+		return fmt.Errorf("%w: signature is %d bytes long, expected 65", ErrInvalidSignature, len(signature))
+	}
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+	if signature[64] > 1 {
+		return fmt.Errorf("%w: invalid recovery id %d", ErrInvalidSignature, signature[64])
+	}
+
+	signer := common.HexToAddress(message.Signer)
+	hash, err := GetHash(signer, common.HexToAddress(message.To), data, nonce, forwarder, chainID)
+	if err != nil {
+		return err
+	}
+	pub, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if recovered := crypto.PubkeyToAddress(*pub); recovered != signer {
+		return fmt.Errorf("%w: signature recovers to %s, not to signer %s", ErrInvalidSignature, recovered.String(), signer.String())
+	}
+	return nil
+}
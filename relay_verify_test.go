@@ -0,0 +1,74 @@
+package rockside_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+func TestVerifyRelayRequest(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	key, _ := crypto.GenerateKey()
+	signer := rockside.NewPrivateKeySigner(key)
+	wallet := srv.NewWalletFor(t, signer.Address())
+	forwarder, smartWallet := wallet.Forwarder.String(), wallet.SmartWallet.String()
+
+	request := func(data, nonce string) rockside.RelayExecuteTxRequest {
+		signature, err := client.Forwarder.SignTxParamsWithSignerWithContext(ctx, signer, forwarder, smartWallet, data, nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rockside.RelayExecuteTxRequest{
+			Signature: signature,
+			Message:   rockside.RelayExecuteTxMessage{Signer: signer.Address().String(), To: smartWallet, Data: data, Nonce: nonce},
+		}
+	}
+
+	valid := request("0x1234", "0")
+	withV27 := valid
+	sig, _ := hexutil.Decode(valid.Signature)
+	sig[64] += 27
+	withV27.Signature = hexutil.Encode(sig)
+	tampered := valid
+	tampered.Message.Data = "0x1235"
+	short := valid
+	short.Signature = valid.Signature[:len(valid.Signature)-2]
+
+	tests := []struct {
+		request rockside.RelayExecuteTxRequest
+		err     error
+	}{
+		{request: valid},
+		{request: withV27},
+		{request: request("0x", "0")},
+		{request: request("1234", "0")},
+		{request: tampered, err: rockside.ErrInvalidSignature},
+		{request: short, err: rockside.ErrInvalidSignature},
+		{request: request("0x1234", "1"), err: rockside.ErrNonceMismatch},
+	}
+	for i, test := range tests {
+		err := client.Forwarder.VerifyRelayRequest(forwarder, test.request)
+		if test.err == nil && err != nil {
+			t.Fatalf("case %d: %v", i+1, err)
+		}
+		if test.err != nil && !errors.Is(err, test.err) {
+			t.Fatalf("case %d: got %v, want %v", i+1, err, test.err)
+		}
+	}
+
+	if _, err := client.Forwarder.Relay(forwarder, valid); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Forwarder.VerifyRelayRequest(forwarder, valid); !errors.Is(err, rockside.ErrNonceMismatch) {
+		t.Fatalf("got %v, want %v", err, rockside.ErrNonceMismatch)
+	}
+}
@@ -15,12 +15,21 @@ type ContractCreationResponse struct {
 }
 
 type paramsResponse struct {
-	Nonce     string            `json:"nonce"`
-	GasPrices map[string]string `json:"gas_prices"`
+	Nonce     string           `json:"nonce"`
+	GasPrices map[Speed]string `json:"gas_prices"`
 }
 
 type RelayParamsResponse struct {
-	Speeds map[string]SpeedInfo `json:"speeds"`
+	Speeds map[Speed]SpeedInfo `json:"speeds"`
+}
+
+// GasPrices returns the gas price of each speed.
+func (r RelayParamsResponse) GasPrices() map[Speed]string {
+	prices := make(map[Speed]string, len(r.Speeds))
+	for speed, info := range r.Speeds {
+		prices[speed] = info.GasPrice
+	}
+	return prices
 }
 
 type SpeedInfo struct {
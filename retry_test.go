@@ -0,0 +1,147 @@
+package rockside
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		status       int
+		ctx          context.Context
+		call         func(c *Client, ctx context.Context) error
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{
+			name: "read retried on 503", failures: 2, status: http.StatusServiceUnavailable,
+			call:         func(c *Client, ctx context.Context) error { _, err := c.EOA.ListWithContext(ctx); return err },
+			wantAttempts: 3,
+		},
+		{
+			name: "read gives up after max attempts", failures: 5, status: http.StatusTooManyRequests,
+			call:         func(c *Client, ctx context.Context) error { _, err := c.EOA.ListWithContext(ctx); return err },
+			wantAttempts: 3, wantErr: true,
+		},
+		{
+			name: "read not retried on 400", failures: 1, status: http.StatusBadRequest,
+			call:         func(c *Client, ctx context.Context) error { _, err := c.EOA.ListWithContext(ctx); return err },
+			wantAttempts: 1, wantErr: true,
+		},
+		{
+			name: "relay params read retried on 503", failures: 1, status: http.StatusServiceUnavailable,
+			call: func(c *Client, ctx context.Context) error {
+				_, err := c.Forwarder.GetRelayParamsWithContext(ctx, "0x618E5C42ECdc63aD84c95D714aFAdA52602Bbac3", "0x268ba693540A7176ae5d3ba9256A18efbe0A63FF")
+				return err
+			},
+			wantAttempts: 2,
+		},
+		{
+			name: "mutating request not retried by default", failures: 1, status: http.StatusBadGateway,
+			call:         func(c *Client, ctx context.Context) error { _, err := c.EOA.CreateWithContext(ctx); return err },
+			wantAttempts: 1, wantErr: true,
+		},
+		{
+			name: "mutating request retried when marked safe", failures: 1, status: http.StatusBadGateway,
+			ctx:          WithRetrySafe(context.Background()),
+			call:         func(c *Client, ctx context.Context) error { _, err := c.EOA.CreateWithContext(ctx); return err },
+			wantAttempts: 2,
+		},
+		{
+			name: "mutating request retried with idempotency key", failures: 1, status: http.StatusInternalServerError,
+			ctx:          WithIdempotencyKey(context.Background(), "key-1"),
+			call:         func(c *Client, ctx context.Context) error { _, err := c.EOA.CreateWithContext(ctx); return err },
+			wantAttempts: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := test.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			var attempts int32
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if n := atomic.AddInt32(&attempts, 1); n <= test.failures {
+					w.WriteHeader(test.status)
+					w.Write([]byte(`{"error":"failure"}`))
+					return
+				}
+				if key := idempotencyKeyFromContext(ctx); r.Header.Get(idempotencyKeyHeader) != key {
+					t.Errorf("got idempotency key %q, want %q", r.Header.Get(idempotencyKeyHeader), key)
+				}
+				if r.Method == http.MethodGet {
+					w.Write([]byte(`[]`))
+					return
+				}
+				w.Write([]byte(`{}`))
+			})
+			c.SetRetryPolicy(testRetryPolicy)
+
+			err := test.call(c, ctx)
+			if test.wantErr && err == nil {
+				t.Fatal("expected error, got none")
+			}
+			if !test.wantErr && err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if got, want := atomic.LoadInt32(&attempts), test.wantAttempts; got != want {
+				t.Fatalf("got %v attempts, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRetryOnConnectionReset(t *testing.T) {
+	var attempts int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatal(err)
+			}
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+	c.SetRetryPolicy(testRetryPolicy)
+
+	if _, err := c.EOA.List(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := atomic.LoadInt32(&attempts), int32(2); got != want {
+		t.Fatalf("got %v attempts, want %v", got, want)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "3", want: 3 * time.Second, ok: true},
+		{value: "-1", ok: false},
+		{value: "Wed, 01 Jan 2020 00:00:10 GMT", want: 10 * time.Second, ok: true},
+		{value: "soon", ok: false},
+	}
+
+	for i, test := range tests {
+		got, ok := parseRetryAfter(test.value, now)
+		if ok != test.ok || got != test.want {
+			t.Fatalf("case %d: got (%v, %v), want (%v, %v)", i+1, got, ok, test.want, test.ok)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package rockside
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrReverted classifies the errors of reverted calls. Use errors.As with a
+// *RevertError to get the revert reason.
+var ErrReverted = errors.New("execution reverted")
+
+// RevertError is returned when a call reverts.
+type RevertError struct {
+	// Reason is the decoded revert reason, if any: the Error(string) message,
+	// the description of a Panic(uint256) code, or a custom error with its arguments.
+	Reason string
+
+	// PanicCode is set for reverts encoded as Panic(uint256).
+	PanicCode *big.Int
+
+	// ErrorName and Args are set for custom errors decoded with a RevertDecoder.
+	ErrorName string
+	Args      []interface{}
+
+	// Data is the raw revert data.
+	Data []byte
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return ErrReverted.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrReverted, e.Reason)
+}
+
+func (e *RevertError) Is(target error) bool {
+	return target == ErrReverted
+}
+
+var (
+	errorStringSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector       = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// Panic codes of the Solidity compiler.
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler inserted panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "conversion to an invalid enum value",
+	0x22: "incorrectly encoded storage byte array",
+	0x31: "pop() on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "too much memory allocated",
+	0x51: "call to a zero-initialized internal function",
+}
+
+// DecodeRevertReason decodes revert data encoded as Error(string).
+func DecodeRevertReason(data []byte) (string, error) {
+	if len(data) < 4 || string(data[:4]) != string(errorStringSelector) {
+		return "", errors.New("revert data is not an Error(string)")
+	}
+
+	typ, _ := abi.NewType("string", "", nil)
+	var reason string
+	if err := (abi.Arguments{{Type: typ}}).Unpack(&reason, data[4:]); err != nil {
+		return "", fmt.Errorf("cannot decode revert reason: %w", err)
+	}
+	return reason, nil
+}
+
+// DecodeRevertPanic decodes revert data encoded as Panic(uint256) and returns the panic code.
+func DecodeRevertPanic(data []byte) (*big.Int, error) {
+	if len(data) < 4 || string(data[:4]) != string(panicSelector) {
+		return nil, errors.New("revert data is not a Panic(uint256)")
+	}
+
+	typ, _ := abi.NewType("uint256", "", nil)
+	code := new(big.Int)
+	if err := (abi.Arguments{{Type: typ}}).Unpack(&code, data[4:]); err != nil {
+		return nil, fmt.Errorf("cannot decode panic code: %w", err)
+	}
+	return code, nil
+}
+
+// RevertDecoder decodes revert data: Error(string), Panic(uint256) and the
+// custom errors declared in the given contract ABIs.
+type RevertDecoder struct {
+	errors map[string]customError
+}
+
+type customError struct {
+	name   string
+	inputs abi.Arguments
+}
+
+// NewRevertDecoder returns a decoder of the custom errors declared in the
+// given JSON ABIs, in addition to Error(string) and Panic(uint256).
+func NewRevertDecoder(jsonABIs ...string) (*RevertDecoder, error) {
+	d := &RevertDecoder{errors: make(map[string]customError)}
+	for _, jsonABI := range jsonABIs {
+		if err := d.addErrors(jsonABI); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// addErrors registers the errors of the ABI. They are parsed here as the abi
+// package does not support them.
+func (d *RevertDecoder) addErrors(jsonABI string) error {
+	var fields []struct {
+		Type   string
+		Name   string
+		Inputs []abiArgument
+	}
+	if err := json.Unmarshal([]byte(jsonABI), &fields); err != nil {
+		return fmt.Errorf("cannot parse ABI: %w", err)
+	}
+
+	for _, f := range fields {
+		if f.Type != "error" {
+			continue
+		}
+		var (
+			inputs abi.Arguments
+			types  []string
+		)
+		for _, in := range f.Inputs {
+			typ, err := abi.NewType(in.Type, in.InternalType, in.Components)
+			if err != nil {
+				return fmt.Errorf("cannot parse error %s: %w", f.Name, err)
+			}
+			inputs = append(inputs, abi.Argument{Name: in.Name, Type: typ})
+			types = append(types, typ.String())
+		}
+		signature := fmt.Sprintf("%s(%s)", f.Name, strings.Join(types, ","))
+		d.errors[string(crypto.Keccak256([]byte(signature))[:4])] = customError{name: f.Name, inputs: inputs}
+	}
+	return nil
+}
+
+type abiArgument struct {
+	Name         string
+	Type         string
+	InternalType string
+	Components   []abi.ArgumentMarshaling
+}
+
+// Decode returns the revert error described by the revert data. The reason
+// is left empty when the data cannot be decoded.
+func (d *RevertDecoder) Decode(data []byte) *RevertError {
+	revert := &RevertError{Data: data}
+	if len(data) < 4 {
+		return revert
+	}
+
+	if reason, err := DecodeRevertReason(data); err == nil {
+		revert.Reason = reason
+		return revert
+	}
+
+	if code, err := DecodeRevertPanic(data); err == nil {
+		revert.PanicCode = code
+		desc := "unknown panic code"
+		if code.IsUint64() {
+			if r, ok := panicReasons[code.Uint64()]; ok {
+				desc = r
+			}
+		}
+		revert.Reason = fmt.Sprintf("panic: %s (0x%x)", desc, code)
+		return revert
+	}
+
+	if d != nil {
+		if e, ok := d.errors[string(data[:4])]; ok {
+			args, err := e.inputs.UnpackValues(data[4:])
+			if err != nil {
+				return revert
+			}
+			revert.ErrorName = e.name
+			revert.Args = args
+			var formatted []string
+			for _, a := range args {
+				formatted = append(formatted, fmt.Sprint(a))
+			}
+			revert.Reason = fmt.Sprintf("%s(%s)", e.name, strings.Join(formatted, ", "))
+		}
+	}
+	return revert
+}
+
+// revertFromRPCError returns the revert error described by the RPC error, or
+// nil when the error is not a revert.
+func revertFromRPCError(e *RPCError, d *RevertDecoder) *RevertError {
+	data := revertData(e.Data)
+	if data == nil && e.Code != 3 && !strings.Contains(strings.ToLower(e.Message), "revert") {
+		return nil
+	}
+
+	revert := d.Decode(data)
+	if revert.Reason == "" {
+		if i := strings.Index(e.Message, "reverted: "); i >= 0 {
+			revert.Reason = e.Message[i+len("reverted: "):]
+		}
+	}
+	return revert
+}
+
+// revertData extracts the revert data from the data of an RPC error, sent
+// as a hex string, possibly prefixed with "Reverted ".
+func revertData(raw json.RawMessage) []byte {
+	var s string
+	if len(raw) == 0 || json.Unmarshal(raw, &s) != nil {
+		return nil
+	}
+	s = strings.TrimPrefix(s, "Reverted ")
+	data, err := hexutil.Decode(s)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,121 @@
+package rockside_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+const errorsABI = `[{"inputs":[{"internalType":"uint256","name":"available","type":"uint256"},{"internalType":"uint256","name":"required","type":"uint256"}],"name":"InsufficientBalance","type":"error"},{"inputs":[],"name":"Unauthorized","type":"error"}]`
+
+func encodeRevert(t *testing.T, signature string, types []string, values ...interface{}) []byte {
+	var args abi.Arguments
+	for _, typ := range types {
+		parsed, err := abi.NewType(typ, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, abi.Argument{Type: parsed})
+	}
+	encoded, err := args.Pack(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(crypto.Keccak256([]byte(signature))[:4], encoded...)
+}
+
+func TestRevertDecoder(t *testing.T) {
+	decoder, err := rockside.NewRevertDecoder(errorsABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		data   []byte
+		reason string
+	}{
+		{data: rocksidetest.Revert("not allowed").(*rockside.RevertError).Data, reason: "not allowed"},
+		{data: encodeRevert(t, "Panic(uint256)", []string{"uint256"}, big.NewInt(0x11)), reason: "panic: arithmetic underflow or overflow (0x11)"},
+		{data: encodeRevert(t, "Panic(uint256)", []string{"uint256"}, big.NewInt(0x99)), reason: "panic: unknown panic code (0x99)"},
+		{data: encodeRevert(t, "InsufficientBalance(uint256,uint256)", []string{"uint256", "uint256"}, big.NewInt(1), big.NewInt(2)), reason: "InsufficientBalance(1, 2)"},
+		{data: encodeRevert(t, "Unauthorized()", nil), reason: "Unauthorized()"},
+		{data: encodeRevert(t, "Unknown()", nil), reason: ""},
+		{data: nil, reason: ""},
+	}
+
+	for i, test := range tests {
+		revert := decoder.Decode(test.data)
+		if got, want := revert.Reason, test.reason; got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+	}
+
+	revert := decoder.Decode(encodeRevert(t, "InsufficientBalance(uint256,uint256)", []string{"uint256", "uint256"}, big.NewInt(1), big.NewInt(2)))
+	if got, want := revert.ErrorName, "InsufficientBalance"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := len(revert.Args), 2; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRevertReason(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	smartWallet := srv.NewWallet(t).SmartWallet.String()
+	srv.MineAll()
+
+	sent, err := client.Transaction.Send(rockside.Transaction{From: smartWallet, To: smartWallet, Data: "0x1234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Fail(sent.TrackingID); err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := client.RPCClient.TransactionReceipt(ctx, common.HexToHash(sent.TransactionHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	customErr := encodeRevert(t, "InsufficientBalance(uint256,uint256)", []string{"uint256", "uint256"}, big.NewInt(1), big.NewInt(2))
+	var replayed ethereum.CallMsg
+	var replayedAt *big.Int
+	srv.CallHandler = func(msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+		replayed, replayedAt = msg, blockNumber
+		return nil, &rockside.RevertError{Data: customErr}
+	}
+
+	decoder, err := rockside.NewRevertDecoder(errorsABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revert, err := client.RPCClient.RevertReason(ctx, common.HexToHash(sent.TransactionHash), decoder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := revert.Reason, "InsufficientBalance(1, 2)"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := common.Bytes2Hex(replayed.Data), "1234"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := replayedAt.Uint64(), receipt.BlockNumber.Uint64()-1; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	mined, _ := client.Transaction.Send(rockside.Transaction{From: smartWallet, To: smartWallet})
+	srv.Mine(mined.TrackingID)
+	if _, err := client.RPCClient.RevertReason(ctx, common.HexToHash(mined.TransactionHash), nil); err == nil {
+		t.Fatal("expected error for successful transaction")
+	}
+}
@@ -0,0 +1,276 @@
+package rocksidetest
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/rocksideio/rockside-sdk-go"
+)
+
+func (s *Server) createEOA(w http.ResponseWriter, r *http.Request) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	s.mu.Lock()
+	s.eoas = append(s.eoas, addr)
+	s.keys[addr] = key
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string]string{"address": addr.String()})
+}
+
+func (s *Server) eoaKey(w http.ResponseWriter, address string) (*ecdsa.PrivateKey, bool) {
+	if !isHexAddress(address) {
+		writeError(w, http.StatusBadRequest, "invalid address")
+		return nil, false
+	}
+	key, ok := s.EOA(common.HexToAddress(address))
+	if !ok {
+		writeError(w, http.StatusNotFound, "EOA not found")
+		return nil, false
+	}
+	return key, true
+}
+
+func (s *Server) signTransaction(w http.ResponseWriter, r *http.Request, address string) {
+	key, ok := s.eoaKey(w, address)
+	if !ok {
+		return
+	}
+
+	var req rockside.SignTransactionRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	var (
+		nonce, gas      uint64
+		value, gasPrice = new(big.Int), new(big.Int)
+		data            []byte
+		err             error
+	)
+	if req.Nonce != "" {
+		if nonce, err = hexutil.DecodeUint64(req.Nonce); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid nonce")
+			return
+		}
+	}
+	if req.Gas != "" {
+		if gas, err = hexutil.DecodeUint64(req.Gas); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid gas")
+			return
+		}
+	}
+	if req.Value != "" {
+		if value, err = hexutil.DecodeBig(req.Value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid value")
+			return
+		}
+	}
+	if req.GasPrice != "" {
+		if gasPrice, err = hexutil.DecodeBig(req.GasPrice); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid gas price")
+			return
+		}
+	}
+	if req.Data != "" {
+		if data, err = hexutil.Decode(req.Data); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid data")
+			return
+		}
+	}
+
+	var tx *types.Transaction
+	if req.To == "" {
+		tx = types.NewContractCreation(nonce, value, gas, gasPrice, data)
+	} else {
+		tx = types.NewTransaction(nonce, common.HexToAddress(req.To), value, gas, gasPrice, data)
+	}
+
+	chainID := s.chainID()
+	if req.NetworkID != "" {
+		id, ok := new(big.Int).SetString(req.NetworkID, 0)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid network ID")
+			return
+		}
+		chainID = id
+	}
+
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(chainID), key)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	raw, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"signed_transaction": hexutil.Encode(raw)})
+}
+
+// signMessage signs the given hex encoded 32 bytes hash, as is unless
+// PrefixedSignMessage is set.
+func (s *Server) signMessage(w http.ResponseWriter, r *http.Request, address string) {
+	key, ok := s.eoaKey(w, address)
+	if !ok {
+		return
+	}
+
+	var req rockside.SignMessageRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	hash, err := hexutil.Decode(req.Message)
+	if err != nil || len(hash) != 32 {
+		writeError(w, http.StatusBadRequest, "message must be a hex encoded 32 bytes hash")
+		return
+	}
+
+	if s.PrefixedSignMessage {
+		hash = accounts.TextHash(hash)
+	}
+
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	sig[64] += 27
+
+	writeJSON(w, http.StatusOK, map[string]string{"signed_message": hexutil.Encode(sig)})
+}
+
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Origin    string   `json:"origin"`
+		EndUserID string   `json:"end_user_id"`
+		Contracts []string `json:"contracts"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Origin == "" {
+		writeError(w, http.StatusBadRequest, "missing origin")
+		return
+	}
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	token := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	s.tokens[token] = req.Origin
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string]string{"token": token})
+}
+
+func (s *Server) createForwarder(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Owner string `json:"owner"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if !isHexAddress(req.Owner) {
+		writeError(w, http.StatusBadRequest, "invalid owner address")
+		return
+	}
+
+	s.mu.Lock()
+	addr := s.newContractAddress()
+	s.forwarders = append(s.forwarders, addr)
+	s.owners[addr] = common.HexToAddress(req.Owner)
+	s.nonces[addr] = make(map[common.Address]uint64)
+	tx := s.submit(s.Relayer, nil, nil, big.NewInt(0))
+	tx.contractAddress = &addr
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, creationResponse(addr, tx))
+}
+
+func (s *Server) createSmartWallet(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Account   string `json:"account"`
+		Forwarder string `json:"forwarder"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if !isHexAddress(req.Account) {
+		writeError(w, http.StatusBadRequest, "invalid account address")
+		return
+	}
+	if !isHexAddress(req.Forwarder) || !s.isContract(common.HexToAddress(req.Forwarder)) {
+		writeError(w, http.StatusBadRequest, "invalid forwarder address")
+		return
+	}
+
+	s.mu.Lock()
+	addr := s.newContractAddress()
+	s.smartWallets = append(s.smartWallets, addr)
+	s.owners[addr] = common.HexToAddress(req.Account)
+	tx := s.submit(s.Relayer, nil, nil, big.NewInt(0))
+	tx.contractAddress = &addr
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, creationResponse(addr, tx))
+}
+
+func (s *Server) forwarderRelayParams(w http.ResponseWriter, r *http.Request, forwarder string) {
+	var req struct {
+		Account   string `json:"account"`
+		ChannelID string `json:"channel_id"`
+	}
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if !isHexAddress(req.Account) {
+		writeError(w, http.StatusBadRequest, "invalid account address")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nonces, ok := s.nonces[common.HexToAddress(forwarder)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "forwarder not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"nonce":      fmt.Sprint(nonces[common.HexToAddress(req.Account)]),
+		"gas_prices": s.GasPrices,
+	})
+}
+
+func (s *Server) relayParams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	speeds := make(map[rockside.Speed]rockside.SpeedInfo)
+	for speed, price := range s.GasPrices {
+		speeds[speed] = rockside.SpeedInfo{GasPrice: price, Relayer: s.Relayer.String()}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"speeds": speeds})
+}
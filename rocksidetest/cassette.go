@@ -0,0 +1,273 @@
+package rocksidetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CassetteMode selects whether a Cassette records or replays interactions.
+type CassetteMode int
+
+const (
+	// ModeReplay serves responses from the cassette file and fails requests
+	// that were not recorded. No request reaches the network.
+	ModeReplay CassetteMode = iota
+
+	// ModeRecord forwards requests to the underlying transport and records
+	// the interactions, to be written to the cassette file by Save.
+	ModeRecord
+)
+
+// Headers never written to cassettes.
+var credentialHeaders = []string{"Apikey", "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "Origin"}
+
+// Interaction is a recorded request/response pair.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as saved in a cassette, without credentials.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response as saved in a cassette.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Cassette is an http.RoundTripper recording REST and JSON-RPC interactions
+// with Rockside to a file, and replaying them offline. Plug it in a client
+// with rockside.WithTransport.
+//
+// Requests are matched on their method, path, query and body (JSON bodies
+// are compared regardless of formatting and, for JSON-RPC, of the request
+// ID). Identical requests are replayed in the order they were recorded.
+// Credential headers are never recorded.
+type Cassette struct {
+	path string
+	mode CassetteMode
+	base http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// NewCassette returns a cassette backed by the file at path. In ModeReplay
+// the file is loaded and must exist. In ModeRecord requests are sent through
+// base (http.DefaultTransport if nil).
+func NewCassette(path string, mode CassetteMode, base http.RoundTripper) (*Cassette, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	c := &Cassette{path: path, mode: mode, base: base}
+
+	if mode == ModeReplay {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %w", err)
+		}
+		if err := json.Unmarshal(b, &c.interactions); err != nil {
+			return nil, fmt.Errorf("cassette %s: %w", path, err)
+		}
+		c.used = make([]bool, len(c.interactions))
+	}
+
+	return c, nil
+}
+
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+		Header: stripCredentials(req.Header),
+		Body:   string(body),
+	}
+
+	if c.mode == ModeReplay {
+		return c.replay(req, recorded)
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp, err := c.base.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, &Interaction{
+		Request:  recorded,
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Header: stripCredentials(resp.Header), Body: string(respBody)},
+	})
+	c.used = append(c.used, true)
+	c.mu.Unlock()
+
+	return resp, nil
+}
+
+func (c *Cassette) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	key := matchKey(recorded)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, in := range c.interactions {
+		if c.used[i] || matchKey(in.Request) != key {
+			continue
+		}
+		c.used[i] = true
+
+		body := []byte(in.Response.Body)
+		if id := jsonRPCID([]byte(recorded.Body)); id != nil {
+			body = withJSONRPCID(body, id)
+		}
+
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s: no recorded interaction for %s %s %s", c.path, recorded.Method, recorded.Path, recorded.Body)
+}
+
+// Save writes the recorded interactions to the cassette file. It is a no-op in ModeReplay.
+func (c *Cassette) Save() error {
+	if c.mode != ModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := json.MarshalIndent(c.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, append(b, '\n'), 0644)
+}
+
+// Unused returns the recorded interactions not replayed yet.
+func (c *Cassette) Unused() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var unused []Interaction
+	for i, in := range c.interactions {
+		if !c.used[i] {
+			unused = append(unused, *in)
+		}
+	}
+	return unused
+}
+
+func stripCredentials(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	clone := h.Clone()
+	for _, k := range credentialHeaders {
+		clone.Del(k)
+	}
+	return clone
+}
+
+func matchKey(r RecordedRequest) string {
+	return fmt.Sprintf("%s %s?%s %s", r.Method, r.Path, r.Query, normalizeBody([]byte(r.Body)))
+}
+
+// normalizeBody returns a canonical form of JSON bodies, without the
+// JSON-RPC request ID. Non JSON bodies are returned as is.
+func normalizeBody(body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if _, ok := val["jsonrpc"]; ok {
+			delete(val, "id")
+		}
+	case []interface{}:
+		for _, item := range val {
+			if m, ok := item.(map[string]interface{}); ok {
+				if _, ok := m["jsonrpc"]; ok {
+					delete(m, "id")
+				}
+			}
+		}
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// jsonRPCID returns the ID of a single JSON-RPC request body.
+func jsonRPCID(body []byte) json.RawMessage {
+	var v struct {
+		Version string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(body, &v); err != nil || v.Version == "" {
+		return nil
+	}
+	return v.ID
+}
+
+func withJSONRPCID(body []byte, id json.RawMessage) []byte {
+	var v map[string]json.RawMessage
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	v["id"] = id
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return b
+}
@@ -0,0 +1,85 @@
+package rocksidetest_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+func TestCassette(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+
+	recorder, err := rocksidetest.NewCassette(path, rocksidetest.ModeRecord, srv.Server.Client().Transport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := rockside.New(rockside.APIKey(rocksidetest.APIKey), srv.Network, rockside.WithBaseURL(srv.URL), rockside.WithTransport(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorded, err := client.EOA.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordedGasPrice, err := client.RPCClient.SuggestGasPrice(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), rocksidetest.APIKey) {
+		t.Fatalf("cassette contains API key: %s", b)
+	}
+
+	player, err := rocksidetest.NewCassette(path, rocksidetest.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err = rockside.New(rockside.APIKey("11111111111111111111111111111111"), srv.Network, rockside.WithTransport(player), rockside.WithRetryPolicy(rockside.NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed, err := client.EOA.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := replayed.Address, recorded.Address; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	replayedGasPrice, err := client.RPCClient.SuggestGasPrice(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := replayedGasPrice.String(), recordedGasPrice.String(); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := len(player.Unused()), 0; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := client.EOA.Create(); err == nil {
+		t.Fatal("expected error for request not recorded")
+	}
+}
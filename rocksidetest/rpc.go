@@ -0,0 +1,230 @@
+package rocksidetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocksideio/rockside-sdk-go"
+)
+
+type rpcRequest struct {
+	ID      json.RawMessage   `json:"id"`
+	Version string            `json:"jsonrpc"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	ID      json.RawMessage    `json:"id"`
+	Version string             `json:"jsonrpc"`
+	Result  interface{}        `json:"result"`
+	Error   *rockside.RPCError `json:"error,omitempty"`
+}
+
+func (s *Server) serveRPC(w http.ResponseWriter, r *http.Request) {
+	var raw json.RawMessage
+	if !decodeBody(w, r, &raw) {
+		return
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+		var batch []rpcRequest
+		if err := json.Unmarshal(raw, &batch); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var responses []rpcResponse
+		for _, req := range batch {
+			responses = append(responses, s.handleRPC(req))
+		}
+		writeJSON(w, http.StatusOK, responses)
+		return
+	}
+
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, s.handleRPC(req))
+}
+
+func (s *Server) handleRPC(req rpcRequest) rpcResponse {
+	resp := rpcResponse{ID: req.ID, Version: "2.0"}
+	result, rpcErr := s.callRPC(req.Method, req.Params)
+	if rpcErr != nil {
+		resp.Error = rpcErr
+	} else {
+		resp.Result = result
+	}
+	return resp
+}
+
+func (s *Server) callRPC(method string, params []json.RawMessage) (interface{}, *rockside.RPCError) {
+	switch method {
+	case "eth_chainId":
+		return hexutil.EncodeBig(s.chainID()), nil
+	case "net_version":
+		return s.chainID().String(), nil
+	case "eth_blockNumber":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return hexutil.EncodeUint64(s.head), nil
+	case "eth_gasPrice":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		price, _ := new(big.Int).SetString(s.GasPrices["standard"], 10)
+		return hexutil.EncodeBig(price), nil
+	case "eth_accounts":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return addressStrings(s.smartWallets), nil
+	case "eth_getCode":
+		var addr common.Address
+		if err := unmarshalParam(params, 0, &addr); err != nil {
+			return nil, err
+		}
+		if s.isContract(addr) {
+			return "0x6080604052", nil
+		}
+		return "0x", nil
+	case "eth_call":
+		return s.call(params)
+	case "eth_sendTransaction":
+		var tx rockside.Transaction
+		if err := unmarshalParam(params, 0, &tx); err != nil {
+			return nil, err
+		}
+		sent, _, errMsg := s.sendFromSmartWallet(tx)
+		if errMsg != "" {
+			return nil, &rockside.RPCError{Code: -32602, Message: errMsg}
+		}
+		return sent.hash.Hex(), nil
+	case "eth_getTransactionByHash":
+		var hash common.Hash
+		if err := unmarshalParam(params, 0, &hash); err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		tx := s.find(hash.Hex())
+		if tx == nil || tx.hash != hash {
+			return nil, nil
+		}
+		return rpcTransaction(tx), nil
+	case "eth_getTransactionReceipt":
+		var hash common.Hash
+		if err := unmarshalParam(params, 0, &hash); err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		tx := s.find(hash.Hex())
+		if tx == nil || tx.hash != hash {
+			return nil, nil
+		}
+		if r := s.receipt(tx); r != nil {
+			return r, nil
+		}
+		return nil, nil
+	default:
+		return nil, &rockside.RPCError{Code: -32601, Message: "the method " + method + " does not exist/is not available"}
+	}
+}
+
+type callArg struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+func (s *Server) call(params []json.RawMessage) (interface{}, *rockside.RPCError) {
+	var arg callArg
+	if err := unmarshalParam(params, 0, &arg); err != nil {
+		return nil, err
+	}
+	var blockNumber *big.Int
+	var block string
+	if len(params) > 1 && json.Unmarshal(params[1], &block) == nil && block != "latest" && block != "pending" {
+		n, err := hexutil.DecodeBig(block)
+		if err != nil {
+			return nil, &rockside.RPCError{Code: -32602, Message: "invalid argument 1: " + err.Error()}
+		}
+		blockNumber = n
+	}
+
+	if s.CallHandler == nil {
+		return "0x", nil
+	}
+
+	msg := ethereum.CallMsg{
+		From:     arg.From,
+		To:       arg.To,
+		Gas:      uint64(arg.Gas),
+		GasPrice: (*big.Int)(arg.GasPrice),
+		Value:    (*big.Int)(arg.Value),
+		Data:     arg.Data,
+	}
+	ret, err := s.CallHandler(msg, blockNumber)
+	if revert, ok := err.(*rockside.RevertError); ok {
+		data, _ := json.Marshal(hexutil.Encode(revert.Data))
+		return nil, &rockside.RPCError{Code: 3, Message: revert.Error(), Data: data}
+	}
+	if err != nil {
+		return nil, &rockside.RPCError{Code: -32000, Message: err.Error()}
+	}
+	return hexutil.Encode(ret), nil
+}
+
+// Revert returns the error to return from a CallHandler to revert the call
+// with the given reason, encoded as Error(string).
+func Revert(reason string) error {
+	typ, _ := abi.NewType("string", "", nil)
+	encoded, _ := abi.Arguments{{Type: typ}}.Pack(reason)
+	data := append(crypto.Keccak256([]byte("Error(string)"))[:4], encoded...)
+	return &rockside.RevertError{Reason: reason, Data: data}
+}
+
+func unmarshalParam(params []json.RawMessage, i int, v interface{}) *rockside.RPCError {
+	if len(params) <= i {
+		return &rockside.RPCError{Code: -32602, Message: "missing value for required argument"}
+	}
+	if err := json.Unmarshal(params[i], v); err != nil {
+		return &rockside.RPCError{Code: -32602, Message: "invalid argument: " + err.Error()}
+	}
+	return nil
+}
+
+// rpcTransaction is the JSON-RPC representation of a transaction. It must be
+// called with the lock held.
+func rpcTransaction(tx *transaction) map[string]interface{} {
+	v := map[string]interface{}{
+		"hash":             tx.hash,
+		"from":             tx.from,
+		"to":               tx.to,
+		"input":            hexutil.Bytes(tx.data),
+		"value":            (*hexutil.Big)(tx.value),
+		"gas":              hexutil.Uint64(tx.gas),
+		"gasPrice":         (*hexutil.Big)(tx.gasPrice),
+		"nonce":            "0x0",
+		"blockHash":        nil,
+		"blockNumber":      nil,
+		"transactionIndex": nil,
+	}
+	if tx.status != StatusPending {
+		v["blockHash"] = blockHash(tx.blockNumber)
+		v["blockNumber"] = hexutil.Uint64(tx.blockNumber)
+		v["transactionIndex"] = "0x0"
+	}
+	return v
+}
@@ -0,0 +1,306 @@
+// Package rocksidetest provides an in-memory fake of the Rockside API to test
+// code built on the Rockside SDK without an API key nor network access.
+//
+// The fake serves the REST endpoints used by the SDK (EOA, forwarders, smart
+// wallets, transactions, tokens and direct relay) and a minimal JSON-RPC
+// endpoint over TLS, as the client requires HTTPS:
+//
+//	srv := rocksidetest.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	eoa, err := client.EOA.Create()
+package rocksidetest
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocksideio/rockside-sdk-go"
+)
+
+// APIKey is the API key accepted by the fake server.
+const APIKey = "00000000000000000000000000000000"
+
+// Hook is invoked before the fake server handles a request. Returning true
+// means the hook has written the response and the request is not handled further.
+type Hook func(w http.ResponseWriter, r *http.Request) bool
+
+// Server is an in-memory fake of the Rockside API.
+type Server struct {
+	*httptest.Server
+
+	// Network is the network of the clients returned by Client.
+	Network rockside.Network
+
+	// Relayer is the address returned as relayer in relay params, and used
+	// as 'from' of relayed transactions.
+	Relayer common.Address
+
+	// GasPrices returned in relay params, per speed.
+	GasPrices map[rockside.Speed]string
+
+	// AutoMine makes submitted transactions mined right away instead of
+	// staying pending until Mine or MineAll is called.
+	AutoMine bool
+
+	// PrefixedSignMessage makes sign-message sign the hash with the
+	// "\x19Ethereum Signed Message:\n32" prefix, as eth_sign does, instead
+	// of signing it as is.
+	PrefixedSignMessage bool
+
+	// CallHandler, when set, handles eth_call requests, at the latest block
+	// when blockNumber is nil. Return the error of Revert to revert the call.
+	// eth_call returns 0x otherwise.
+	CallHandler func(msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+
+	mu           sync.Mutex
+	hooks        []Hook
+	failures     []injectedFailure
+	requestCount int
+
+	eoas         []common.Address
+	keys         map[common.Address]*ecdsa.PrivateKey
+	forwarders   []common.Address
+	owners       map[common.Address]common.Address
+	nonces       map[common.Address]map[common.Address]uint64
+	smartWallets []common.Address
+	tokens       map[string]string
+	contracts    map[common.Address]bool
+	contractNum  uint64
+	txs          []*transaction
+	head         uint64
+}
+
+type injectedFailure struct {
+	status int
+	body   string
+}
+
+// NewServer starts and returns a new fake Rockside server. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		Network: rockside.Testnet,
+		Relayer: common.HexToAddress("0x618E5C42ECdc63aD84c95D714aFAdA52602Bbac3"),
+		GasPrices: map[rockside.Speed]string{
+			rockside.SpeedFastest:  "30000000000",
+			rockside.SpeedFast:     "20000000000",
+			rockside.SpeedStandard: "10000000000",
+			rockside.SpeedSafeLow:  "5000000000",
+		},
+		keys:      make(map[common.Address]*ecdsa.PrivateKey),
+		owners:    make(map[common.Address]common.Address),
+		nonces:    make(map[common.Address]map[common.Address]uint64),
+		tokens:    make(map[string]string),
+		contracts: make(map[common.Address]bool),
+		head:      1,
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a Rockside client configured to talk to the fake server.
+func (s *Server) Client(opts ...rockside.Option) *rockside.Client {
+	opts = append([]rockside.Option{
+		rockside.WithBaseURL(s.URL),
+		rockside.WithTransport(s.Server.Client().Transport),
+	}, opts...)
+
+	c, err := rockside.New(rockside.APIKey(APIKey), s.Network, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("rocksidetest: cannot create client: %s", err))
+	}
+	return c
+}
+
+// AddHook registers a hook invoked before each request is handled, for
+// instance to inject errors on specific endpoints.
+func (s *Server) AddHook(h Hook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, h)
+}
+
+// FailNext makes the next n requests fail with the given status and a JSON error.
+func (s *Server) FailNext(n int, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, injectedFailure{status: status, body: fmt.Sprintf(`{"error":"injected failure (%d)"}`, status)})
+	}
+}
+
+// EOA returns the private key of an EOA created through the fake server.
+func (s *Server) EOA(address common.Address) (*ecdsa.PrivateKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[address]
+	return key, ok
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requestCount++
+	w.Header().Set("X-Request-ID", fmt.Sprintf("fake-%d", s.requestCount))
+	hooks := append([]Hook(nil), s.hooks...)
+	var failure *injectedFailure
+	if len(s.failures) > 0 {
+		failure = &s.failures[0]
+		s.failures = s.failures[1:]
+	}
+	s.mu.Unlock()
+
+	for _, h := range hooks {
+		if h(w, r) {
+			return
+		}
+	}
+	if failure != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(failure.status)
+		w.Write([]byte(failure.body))
+		return
+	}
+
+	if !s.authenticated(r) {
+		writeError(w, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "tokens" && r.Method == http.MethodPost:
+		s.createToken(w, r)
+	case len(parts) >= 2 && parts[0] == "ethereum" && parts[1] == "eoa":
+		s.serveEOA(w, r, parts[2:])
+	case len(parts) == 3 && parts[0] == "ethereum" && parts[2] == "jsonrpc" && r.Method == http.MethodPost:
+		s.serveRPC(w, r)
+	case len(parts) >= 3 && parts[0] == "ethereum":
+		s.serveNetwork(w, r, parts[2], parts[3:])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) authenticated(r *http.Request) bool {
+	if r.Header.Get("apikey") == APIKey {
+		return true
+	}
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		origin, ok := s.tokens[strings.TrimPrefix(auth, "Bearer ")]
+		return ok && origin == r.Header.Get("Origin")
+	}
+	return false
+}
+
+func (s *Server) serveEOA(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.createEOA(w, r)
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeJSON(w, http.StatusOK, addressStrings(s.eoas))
+	case len(parts) == 2 && parts[1] == "sign" && r.Method == http.MethodPost:
+		s.signTransaction(w, r, parts[0])
+	case len(parts) == 2 && parts[1] == "sign-message" && r.Method == http.MethodPost:
+		s.signMessage(w, r, parts[0])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) serveNetwork(w http.ResponseWriter, r *http.Request, resource string, parts []string) {
+	switch {
+	case resource == "forwarders" && len(parts) == 0 && r.Method == http.MethodPost:
+		s.createForwarder(w, r)
+	case resource == "forwarders" && len(parts) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeJSON(w, http.StatusOK, addressStrings(s.forwarders))
+	case resource == "forwarders" && len(parts) == 2 && parts[1] == "relayParams" && r.Method == http.MethodPost:
+		s.forwarderRelayParams(w, r, parts[0])
+	case resource == "forwarders" && len(parts) == 1 && r.Method == http.MethodPost:
+		s.forwarderRelay(w, r, parts[0])
+	case resource == "smartwallets" && len(parts) == 0 && r.Method == http.MethodPost:
+		s.createSmartWallet(w, r)
+	case resource == "smartwallets" && len(parts) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeJSON(w, http.StatusOK, addressStrings(s.smartWallets))
+	case resource == "transaction" && len(parts) == 0 && r.Method == http.MethodPost:
+		s.sendTransaction(w, r)
+	case resource == "transactions" && len(parts) == 1 && r.Method == http.MethodGet:
+		s.showTransaction(w, r, parts[0])
+	case resource == "relay" && len(parts) == 2 && parts[1] == "params" && r.Method == http.MethodGet:
+		s.relayParams(w, r)
+	case resource == "relay" && len(parts) == 1 && r.Method == http.MethodPost:
+		s.relay(w, r, parts[0])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) chainID() *big.Int {
+	return s.Network.ChainID()
+}
+
+// newContractAddress returns the address of a new contract deployed by the relayer.
+func (s *Server) newContractAddress() common.Address {
+	addr := crypto.CreateAddress(s.Relayer, s.contractNum)
+	s.contractNum++
+	s.contracts[addr] = true
+	return addr
+}
+
+func (s *Server) isContract(addr common.Address) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.contracts[addr]
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %s", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func addressStrings(addrs []common.Address) []string {
+	all := []string{}
+	for _, a := range addrs {
+		all = append(all, a.String())
+	}
+	return all
+}
+
+func isHexAddress(s string) bool {
+	return common.IsHexAddress(s)
+}
+
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
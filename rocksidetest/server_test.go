@@ -0,0 +1,196 @@
+package rocksidetest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+func TestServer(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	t.Run("EOA", func(t *testing.T) {
+		eoa, err := client.EOA.Create()
+		if err != nil {
+			t.Fatal(err)
+		}
+		listing, err := client.EOA.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := listing[len(listing)-1], eoa.Address; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		hash := crypto.Keccak256([]byte("hello"))
+		sig, err := client.EOA.SignMessage(eoa.Address, rockside.SignMessageRequest{Message: common.Bytes2Hex(hash)})
+		if err == nil {
+			t.Fatalf("expected error for non 0x prefixed message, got signature %s", sig)
+		}
+		sig, err = client.EOA.SignMessage(eoa.Address, rockside.SignMessageRequest{Message: "0x" + common.Bytes2Hex(hash)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigBytes := common.FromHex(sig)
+		sigBytes[64] -= 27
+		pub, err := crypto.SigToPub(hash, sigBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := crypto.PubkeyToAddress(*pub).String(), eoa.Address; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Forwarder relay", func(t *testing.T) {
+		key, _ := crypto.GenerateKey()
+		owner := crypto.PubkeyToAddress(key.PublicKey)
+		privateKey := common.Bytes2Hex(crypto.FromECDSA(key))
+
+		forwarder, err := client.Forwarder.Create(owner.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		smartWallet, err := client.SmartWallets.Create(owner.String(), forwarder.Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, wantNonce := range []string{"0", "1"} {
+			params, err := client.Forwarder.GetRelayParams(forwarder.Address, owner.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := params.Nonce, wantNonce; got != want {
+				t.Fatalf("relay %d: got %v, want %v", i, got, want)
+			}
+
+			signature, err := client.Forwarder.SignTxParams(privateKey, forwarder.Address, owner.String(), smartWallet.Address, "0x", params.Nonce)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Forwarder.Relay(forwarder.Address, rockside.RelayExecuteTxRequest{
+				Signature: signature,
+				Message:   rockside.RelayExecuteTxMessage{Signer: owner.String(), To: smartWallet.Address, Data: "0x", Nonce: params.Nonce},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(resp.TransactionHash), 66; got != want {
+				t.Fatalf("relay %d: got %v, want %v", i, got, want)
+			}
+		}
+
+		_, err = client.Forwarder.Relay(forwarder.Address, rockside.RelayExecuteTxRequest{
+			Signature: "0x" + common.Bytes2Hex(make([]byte, 65)),
+			Message:   rockside.RelayExecuteTxMessage{Signer: owner.String(), To: smartWallet.Address, Nonce: "2"},
+		})
+		if !errors.Is(err, rockside.ErrValidation) {
+			t.Fatalf("expected validation error for invalid signature, got %v", err)
+		}
+	})
+
+	t.Run("Transactions", func(t *testing.T) {
+		eoa, _ := client.EOA.Create()
+		forwarder, _ := client.Forwarder.Create(eoa.Address)
+		smartWallet, err := client.SmartWallets.Create(eoa.Address, forwarder.Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sent, err := client.Transaction.Send(rockside.Transaction{From: smartWallet.Address, To: smartWallet.Address, Value: "0x0"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		receipt, err := client.RPCClient.TransactionReceipt(ctx, common.HexToHash(sent.TransactionHash))
+		if err == nil {
+			t.Fatalf("expected no receipt for pending transaction, got %v", receipt)
+		}
+
+		if err := srv.Mine(sent.TrackingID); err != nil {
+			t.Fatal(err)
+		}
+		receipt, err = client.RPCClient.TransactionReceipt(ctx, common.HexToHash(sent.TransactionHash))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := receipt.Status, uint64(1); got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		status, err := client.Transaction.Show(sent.TrackingID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := status.Status, rockside.TxStatusMined; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := status.Receipt.TxHash, common.HexToHash(sent.TransactionHash); got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if _, err := client.Transaction.Show("unknown"); !errors.Is(err, rockside.ErrNotFound) {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("JSON-RPC", func(t *testing.T) {
+		chainID, err := client.RPCClient.ChainID(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := chainID.Int64(), rockside.Testnet.ChainID().Int64(); got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if _, err := client.RPCClient.EthAccounts(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Tokens", func(t *testing.T) {
+		token, err := client.Tokens.Create("example.com", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tokenClient, err := rockside.New(rockside.Token{Token: token.Token, Origin: "example.com"}, srv.Network,
+			rockside.WithBaseURL(srv.URL), rockside.WithTransport(srv.Server.Client().Transport))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tokenClient.SmartWallets.List(); err != nil {
+			t.Fatal(err)
+		}
+
+		badClient, err := rockside.New(rockside.Token{Token: "wrong", Origin: "example.com"}, srv.Network,
+			rockside.WithBaseURL(srv.URL), rockside.WithTransport(srv.Server.Client().Transport))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := badClient.SmartWallets.List(); !errors.Is(err, rockside.ErrUnauthorized) {
+			t.Fatalf("expected unauthorized error, got %v", err)
+		}
+	})
+
+	t.Run("Error injection", func(t *testing.T) {
+		noRetryClient := srv.Client(rockside.WithRetryPolicy(rockside.NoRetry))
+
+		srv.FailNext(1, http.StatusServiceUnavailable)
+		if _, err := noRetryClient.EOA.List(); !errors.Is(err, rockside.ErrServerError) {
+			t.Fatalf("expected server error, got %v", err)
+		}
+		if _, err := noRetryClient.EOA.List(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
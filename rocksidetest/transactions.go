@@ -0,0 +1,435 @@
+package rocksidetest
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocksideio/rockside-sdk-go"
+)
+
+// Transaction statuses reported by the fake server.
+const (
+	StatusPending  = "pending"
+	StatusMined    = "mined"
+	StatusFailed   = "failed"
+	StatusReplaced = "replaced"
+)
+
+type transaction struct {
+	trackingID      string
+	hash            common.Hash
+	previousHashes  []common.Hash
+	from            common.Address
+	to              *common.Address
+	data            []byte
+	value           *big.Int
+	gas             uint64
+	gasPrice        *big.Int
+	status          string
+	contractAddress *common.Address
+	blockNumber     uint64
+	logs            []*types.Log
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+// submit records a new pending transaction. It must be called with the lock held.
+func (s *Server) submit(from common.Address, to *common.Address, data []byte, value *big.Int) *transaction {
+	t := now()
+	tx := &transaction{
+		trackingID: fmt.Sprintf("01FAKE%020d", len(s.txs)+1),
+		hash:       randomHash(),
+		from:       from,
+		to:         to,
+		data:       data,
+		value:      value,
+		gas:        21000 + uint64(len(data))*68,
+		status:     StatusPending,
+		createdAt:  t,
+		updatedAt:  t,
+	}
+	if price, ok := new(big.Int).SetString(s.GasPrices["standard"], 10); ok {
+		tx.gasPrice = price
+	} else {
+		tx.gasPrice = big.NewInt(0)
+	}
+	s.txs = append(s.txs, tx)
+
+	if s.AutoMine {
+		s.mine(tx, true)
+	}
+	return tx
+}
+
+// find returns the transaction with the given tracking ID or hash (current
+// or previous). It must be called with the lock held.
+func (s *Server) find(idOrHash string) *transaction {
+	for _, tx := range s.txs {
+		if strings.EqualFold(tx.trackingID, idOrHash) || strings.EqualFold(tx.hash.Hex(), idOrHash) {
+			return tx
+		}
+		for _, h := range tx.previousHashes {
+			if strings.EqualFold(h.Hex(), idOrHash) {
+				return tx
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) mine(tx *transaction, success bool) {
+	s.head++
+	tx.blockNumber = s.head
+	tx.status = StatusMined
+	if !success {
+		tx.status = StatusFailed
+	}
+	tx.updatedAt = now()
+}
+
+// Mine mines the pending transaction with the given tracking ID or hash in a new block.
+func (s *Server) Mine(idOrHash string) error {
+	return s.mineWithStatus(idOrHash, true)
+}
+
+// Fail mines the pending transaction with the given tracking ID or hash in
+// a new block, with a failed receipt status.
+func (s *Server) Fail(idOrHash string) error {
+	return s.mineWithStatus(idOrHash, false)
+}
+
+func (s *Server) mineWithStatus(idOrHash string, success bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := s.find(idOrHash)
+	if tx == nil {
+		return fmt.Errorf("rocksidetest: no transaction %s", idOrHash)
+	}
+	if tx.status != StatusPending {
+		return fmt.Errorf("rocksidetest: transaction %s is not pending but %s", idOrHash, tx.status)
+	}
+	s.mine(tx, success)
+	return nil
+}
+
+// MineAll mines all pending transactions successfully.
+func (s *Server) MineAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tx := range s.txs {
+		if tx.status == StatusPending {
+			s.mine(tx, true)
+		}
+	}
+}
+
+// Replace simulates Rockside speeding up the pending transaction with the
+// given tracking ID or hash: it is resent with a new hash and a higher gas price.
+// It returns the new transaction hash.
+func (s *Server) Replace(idOrHash string) (common.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := s.find(idOrHash)
+	if tx == nil {
+		return common.Hash{}, fmt.Errorf("rocksidetest: no transaction %s", idOrHash)
+	}
+	if tx.status != StatusPending {
+		return common.Hash{}, fmt.Errorf("rocksidetest: transaction %s is not pending but %s", idOrHash, tx.status)
+	}
+	tx.previousHashes = append(tx.previousHashes, tx.hash)
+	tx.hash = randomHash()
+	tx.gasPrice = new(big.Int).Div(new(big.Int).Mul(tx.gasPrice, big.NewInt(12)), big.NewInt(10))
+	tx.updatedAt = now()
+	return tx.hash, nil
+}
+
+// AddLogs attaches logs to the receipt of the transaction with the given tracking ID or hash.
+func (s *Server) AddLogs(idOrHash string, logs ...*types.Log) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := s.find(idOrHash)
+	if tx == nil {
+		return fmt.Errorf("rocksidetest: no transaction %s", idOrHash)
+	}
+	tx.logs = append(tx.logs, logs...)
+	return nil
+}
+
+// AdvanceBlocks mines n empty blocks.
+func (s *Server) AdvanceBlocks(n uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.head += n
+}
+
+// receipt must be called with the lock held.
+func (s *Server) receipt(tx *transaction) *types.Receipt {
+	if tx.status == StatusPending {
+		return nil
+	}
+
+	r := &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: tx.gas,
+		GasUsed:           tx.gas,
+		Logs:              []*types.Log{},
+		TxHash:            tx.hash,
+		BlockHash:         blockHash(tx.blockNumber),
+		BlockNumber:       new(big.Int).SetUint64(tx.blockNumber),
+	}
+	if tx.status == StatusFailed {
+		r.Status = types.ReceiptStatusFailed
+	}
+	if tx.contractAddress != nil {
+		r.ContractAddress = *tx.contractAddress
+	}
+	for i, l := range tx.logs {
+		cpy := *l
+		cpy.TxHash = tx.hash
+		cpy.BlockNumber = tx.blockNumber
+		cpy.BlockHash = r.BlockHash
+		cpy.Index = uint(i)
+		r.Logs = append(r.Logs, &cpy)
+	}
+	r.Bloom = types.CreateBloom(types.Receipts{r})
+	return r
+}
+
+// view is the JSON representation of a transaction returned by the show endpoint.
+func (s *Server) view(tx *transaction) map[string]interface{} {
+	previous := []string{}
+	for _, h := range tx.previousHashes {
+		previous = append(previous, h.Hex())
+	}
+	v := map[string]interface{}{
+		"tracking_id":                 tx.trackingID,
+		"transaction_hash":            tx.hash.Hex(),
+		"previous_transaction_hashes": previous,
+		"from":                        tx.from.String(),
+		"data":                        hexutil.Encode(tx.data),
+		"value":                       hexutil.EncodeBig(tx.value),
+		"gas":                         hexutil.EncodeUint64(tx.gas),
+		"gas_price":                   hexutil.EncodeBig(tx.gasPrice),
+		"status":                      tx.status,
+		"created_at":                  tx.createdAt,
+		"updated_at":                  tx.updatedAt,
+	}
+	if tx.to != nil {
+		v["to"] = tx.to.String()
+	}
+	if tx.status != StatusPending {
+		v["block_number"] = tx.blockNumber
+		v["receipt"] = s.receipt(tx)
+	}
+	return v
+}
+
+func (s *Server) showTransaction(w http.ResponseWriter, r *http.Request, idOrHash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := s.find(idOrHash)
+	if tx == nil {
+		writeError(w, http.StatusNotFound, "transaction not found")
+		return
+	}
+	v := s.view(tx)
+	// Looked up by one of the hashes it replaced.
+	for _, h := range tx.previousHashes {
+		if strings.EqualFold(h.Hex(), idOrHash) {
+			v["status"] = StatusReplaced
+		}
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func (s *Server) sendTransaction(w http.ResponseWriter, r *http.Request) {
+	var req rockside.Transaction
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	tx, contract, errMsg := s.sendFromSmartWallet(req)
+	if errMsg != "" {
+		writeError(w, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	var addr common.Address
+	if contract != nil {
+		addr = *contract
+	}
+	writeJSON(w, http.StatusCreated, creationResponse(addr, tx))
+}
+
+// sendFromSmartWallet records a transaction sent from one of the smart
+// wallets, returning the created contract address for contract creations.
+func (s *Server) sendFromSmartWallet(req rockside.Transaction) (*transaction, *common.Address, string) {
+	if !isHexAddress(req.From) {
+		return nil, nil, "invalid 'from' address"
+	}
+	if req.To != "" && !isHexAddress(req.To) {
+		return nil, nil, "invalid 'to' address"
+	}
+	from := common.HexToAddress(req.From)
+
+	value := big.NewInt(0)
+	if req.Value != "" {
+		v, err := hexutil.DecodeBig(req.Value)
+		if err != nil {
+			return nil, nil, "invalid 'value' number"
+		}
+		value = v
+	}
+	var data []byte
+	if req.Data != "" {
+		d, err := hexutil.Decode(req.Data)
+		if err != nil {
+			return nil, nil, "invalid 'data' bytes"
+		}
+		data = d
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var known bool
+	for _, sw := range s.smartWallets {
+		if sw == from {
+			known = true
+		}
+	}
+	if !known {
+		return nil, nil, fmt.Sprintf("'from' address %s is not one of your smart wallets", from.String())
+	}
+
+	var to, contract *common.Address
+	if req.To != "" {
+		addr := common.HexToAddress(req.To)
+		to = &addr
+	} else {
+		addr := crypto.CreateAddress(from, uint64(len(s.txs)))
+		s.contracts[addr] = true
+		contract = &addr
+	}
+
+	tx := s.submit(from, to, data, value)
+	tx.contractAddress = contract
+	return tx, contract, ""
+}
+
+func (s *Server) forwarderRelay(w http.ResponseWriter, r *http.Request, forwarder string) {
+	var req rockside.RelayExecuteTxRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if !isHexAddress(req.Message.Signer) || !isHexAddress(req.Message.To) {
+		writeError(w, http.StatusBadRequest, "invalid message addresses")
+		return
+	}
+	nonce, ok := new(big.Int).SetString(req.Message.Nonce, 10)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid message nonce")
+		return
+	}
+	signer := common.HexToAddress(req.Message.Signer)
+	forwarderAddr := common.HexToAddress(forwarder)
+	data := common.FromHex(req.Message.Data)
+
+	hash, err := rockside.GetHash(signer, common.HexToAddress(req.Message.To), data, nonce, forwarderAddr, s.chainID())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if recovered, err := recoverSigner(hash, req.Signature); err != nil || recovered != signer {
+		writeError(w, http.StatusBadRequest, "invalid signature")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nonces, ok := s.nonces[forwarderAddr]
+	if !ok {
+		writeError(w, http.StatusNotFound, "forwarder not found")
+		return
+	}
+	if nonces[signer] != nonce.Uint64() {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid nonce: expected %d", nonces[signer]))
+		return
+	}
+	nonces[signer]++
+
+	tx := s.submit(s.Relayer, &forwarderAddr, data, big.NewInt(0))
+	writeJSON(w, http.StatusOK, relayResponse(tx))
+}
+
+func (s *Server) relay(w http.ResponseWriter, r *http.Request, destination string) {
+	var req rockside.RelayTx
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if !isHexAddress(destination) {
+		writeError(w, http.StatusBadRequest, "invalid destination address")
+		return
+	}
+	data, err := hexutil.Decode(req.Data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid data")
+		return
+	}
+
+	to := common.HexToAddress(destination)
+	s.mu.Lock()
+	tx := s.submit(s.Relayer, &to, data, big.NewInt(0))
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, relayResponse(tx))
+}
+
+func recoverSigner(hash []byte, signature string) (common.Address, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(sig) != 65 {
+		return common.Address{}, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	sig = append([]byte(nil), sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+func creationResponse(addr common.Address, tx *transaction) map[string]string {
+	resp := relayResponse(tx)
+	if addr != (common.Address{}) {
+		resp["address"] = addr.String()
+	}
+	return resp
+}
+
+func relayResponse(tx *transaction) map[string]string {
+	return map[string]string{"transaction_hash": tx.hash.Hex(), "tracking_id": tx.trackingID}
+}
+
+func randomHash() common.Hash {
+	var h common.Hash
+	rand.Read(h[:])
+	return h
+}
+
+func blockHash(number uint64) common.Hash {
+	return crypto.Keccak256Hash(new(big.Int).SetUint64(number).Bytes())
+}
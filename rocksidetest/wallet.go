@@ -0,0 +1,56 @@
+package rocksidetest
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Wallet is a smart wallet created on the fake server, with the forwarder
+// relaying its meta-transactions and its owner.
+type Wallet struct {
+	// OwnerKey is the private key of the owner, nil when the wallet was
+	// created with NewWalletFor.
+	OwnerKey *ecdsa.PrivateKey
+
+	Owner       common.Address
+	Forwarder   common.Address
+	SmartWallet common.Address
+}
+
+// NewWallet creates a forwarder and a smart wallet owned by a new key,
+// through the API of the server. It fails the test on any error.
+func (s *Server) NewWallet(t testing.TB) Wallet {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := s.NewWalletFor(t, crypto.PubkeyToAddress(key.PublicKey))
+	w.OwnerKey = key
+	return w
+}
+
+// NewWalletFor creates a forwarder and a smart wallet owned by the given
+// address, through the API of the server. It fails the test on any error.
+func (s *Server) NewWalletFor(t testing.TB, owner common.Address) Wallet {
+	t.Helper()
+
+	client := s.Client()
+	forwarder, err := client.Forwarder.Create(owner.String())
+	if err != nil {
+		t.Fatalf("rocksidetest: cannot create forwarder: %v", err)
+	}
+	smartWallet, err := client.SmartWallets.Create(owner.String(), forwarder.Address)
+	if err != nil {
+		t.Fatalf("rocksidetest: cannot create smart wallet: %v", err)
+	}
+	return Wallet{
+		Owner:       owner,
+		Forwarder:   common.HexToAddress(forwarder.Address),
+		SmartWallet: common.HexToAddress(smartWallet.Address),
+	}
+}
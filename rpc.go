@@ -2,17 +2,26 @@ package rockside
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"math/big"
 	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 type RPCClient struct {
 	endpoint       *url.URL
+	network        Network
 	authHTTPClient *http.Client
 	*ethclient.Client
 }
@@ -28,27 +37,25 @@ type rpcResponse struct {
 	ID      uint        `json:"id"`
 	Version string      `json:"jsonrpc"`
 	Result  interface{} `json:"result,omitempty"`
-	Error   *rpcError   `json:"error,omitempty"`
+	Error   *RPCError   `json:"error,omitempty"`
 }
 
-type rpcError struct {
-	endpoint string
-	Code     int    `json:"code"`
-	Message  string `json:"message"`
+func (r *RPCClient) SendRocksideTransaction(tx Transaction) (string, error) {
+	return r.SendRocksideTransactionWithContext(context.Background(), tx)
 }
 
-func (e *rpcError) Error() string {
-	return fmt.Sprintf("rpc error: %s (code=%d, url=%s)", e.Message, e.Code, e.endpoint)
+func (r *RPCClient) SendRocksideTransactionWithContext(ctx context.Context, tx Transaction) (string, error) {
+	return r.sendTransaction(ctx, tx)
 }
 
-func (r *RPCClient) SendRocksideTransaction(tx Transaction) (string, error) {
-	return r.sendTransaction(tx)
+func (r *RPCClient) SendTransactionFromSmartWallet(tx Transaction) (string, error) {
+	return r.SendTransactionFromSmartWalletWithContext(context.Background(), tx)
 }
 
-func (r *RPCClient) SendTransactionFromSmartWallet(tx Transaction) (string, error) {
-	accounts, err := r.EthAccounts()
+func (r *RPCClient) SendTransactionFromSmartWalletWithContext(ctx context.Context, tx Transaction) (string, error) {
+	accounts, err := r.EthAccountsWithContext(ctx)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
 	var found bool
@@ -61,10 +68,14 @@ func (r *RPCClient) SendTransactionFromSmartWallet(tx Transaction) (string, erro
 		return "", fmt.Errorf("transaction 'from' address '%s' is not one of your existing Rockside smart wallets", tx.From)
 	}
 
-	return r.sendTransaction(tx)
+	return r.sendTransaction(ctx, tx)
 }
 
 func (r *RPCClient) EthAccounts() ([]string, error) {
+	return r.EthAccountsWithContext(context.Background())
+}
+
+func (r *RPCClient) EthAccountsWithContext(ctx context.Context) ([]string, error) {
 	body := &rpcRequest{ID: 1, Version: "2.0",
 		Method: "eth_accounts",
 		Params: []string{},
@@ -74,14 +85,145 @@ func (r *RPCClient) EthAccounts() ([]string, error) {
 		Result []string `json:"result"`
 	}{}
 
-	if err := r.post(body, &resp); err != nil {
+	if err := r.post(ctx, body, &resp); err != nil {
 		return []string{}, err
 	}
 
 	return resp.Result, nil
 }
 
-func (r *RPCClient) sendTransaction(tx Transaction) (string, error) {
+func (r *RPCClient) EthCall(msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return r.EthCallWithContext(context.Background(), msg, blockNumber)
+}
+
+// EthCallWithContext executes the call with eth_call at the given block (the
+// latest one when nil). When the call reverts, the returned error is a
+// *RevertError holding the revert data and reason.
+func (r *RPCClient) EthCallWithContext(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return r.ethCall(ctx, msg, blockNumber, nil)
+}
+
+func (r *RPCClient) ethCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, decoder *RevertDecoder) ([]byte, error) {
+	arg := map[string]interface{}{
+		"from": msg.From,
+	}
+	if msg.To != nil {
+		arg["to"] = msg.To
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+
+	block := "latest"
+	if blockNumber != nil {
+		block = hexutil.EncodeBig(blockNumber)
+	}
+
+	body := &rpcRequest{ID: 1, Version: "2.0",
+		Method: "eth_call",
+		Params: []interface{}{arg, block},
+	}
+
+	resp := struct {
+		Result hexutil.Bytes `json:"result"`
+	}{}
+
+	if err := r.post(ctx, body, &resp); err != nil {
+		var rpcErr *RPCError
+		if errors.As(err, &rpcErr) {
+			if revert := revertFromRPCError(rpcErr, decoder); revert != nil {
+				return nil, revert
+			}
+		}
+		return nil, err
+	}
+
+	return resp.Result, nil
+}
+
+// RevertReason replays the failed transaction with eth_call on the state of
+// the block preceding the one it was mined in, and returns its revert error
+// decoded with the given decoder (Error(string) and Panic(uint256) only when nil).
+func (r *RPCClient) RevertReason(ctx context.Context, txHash common.Hash, decoder *RevertDecoder) (*RevertError, error) {
+	receipt, err := r.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusFailed {
+		return nil, fmt.Errorf("transaction %s did not fail", txHash.Hex())
+	}
+
+	body := &rpcRequest{ID: 1, Version: "2.0",
+		Method: "eth_getTransactionByHash",
+		Params: []string{txHash.Hex()},
+	}
+	resp := struct {
+		Result *struct {
+			From  common.Address  `json:"from"`
+			To    *common.Address `json:"to"`
+			Input hexutil.Bytes   `json:"input"`
+			Value *hexutil.Big    `json:"value"`
+			Gas   hexutil.Uint64  `json:"gas"`
+		} `json:"result"`
+	}{}
+	if err := r.post(ctx, body, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Result == nil {
+		return nil, ethereum.NotFound
+	}
+
+	msg := ethereum.CallMsg{
+		From:  resp.Result.From,
+		To:    resp.Result.To,
+		Data:  resp.Result.Input,
+		Value: (*big.Int)(resp.Result.Value),
+		Gas:   uint64(resp.Result.Gas),
+	}
+	block := receipt.BlockNumber
+	if block != nil && block.Sign() > 0 {
+		block = new(big.Int).Sub(block, big.NewInt(1))
+	}
+
+	_, err = r.ethCall(ctx, msg, block, decoder)
+	var revert *RevertError
+	if errors.As(err, &revert) {
+		return revert, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("cannot reproduce the failure of transaction %s", txHash.Hex())
+}
+
+// BlockNumber returns the number of the most recent block.
+func (r *RPCClient) BlockNumber(ctx context.Context) (uint64, error) {
+	body := &rpcRequest{ID: 1, Version: "2.0",
+		Method: "eth_blockNumber",
+		Params: []string{},
+	}
+
+	resp := struct {
+		Result hexutil.Uint64 `json:"result"`
+	}{}
+
+	if err := r.post(ctx, body, &resp); err != nil {
+		return 0, err
+	}
+
+	return uint64(resp.Result), nil
+}
+
+func (r *RPCClient) sendTransaction(ctx context.Context, tx Transaction) (string, error) {
 	if err := tx.validateFields(); err != nil {
 		return "", err
 	}
@@ -95,7 +237,7 @@ func (r *RPCClient) sendTransaction(tx Transaction) (string, error) {
 		Result string `json:"result"`
 	}{}
 
-	if err := r.post(body, &resp); err != nil {
+	if err := r.post(ctx, body, &resp); err != nil {
 		return "", err
 	}
 
@@ -103,24 +245,38 @@ func (r *RPCClient) sendTransaction(tx Transaction) (string, error) {
 
 }
 
-func (r *RPCClient) post(body interface{}, decode interface{}) error {
+func (r *RPCClient) post(ctx context.Context, body interface{}, decode interface{}) error {
 	b, err := json.Marshal(body)
 	if err != nil {
 		return fmt.Errorf("cannot marshal RPC request to JSON: %s", err)
 	}
 
-	resp, err := r.authHTTPClient.Post(r.endpoint.String(), "", bytes.NewReader(b))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint.String(), bytes.NewReader(b))
 	if err != nil {
 		return err
 	}
 
+	resp, err := r.authHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if status := resp.StatusCode; status > 299 || status < 200 {
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return newAPIError(resp, b, r.network)
+	}
+
 	var rpcResp rpcResponse
 	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
 		return fmt.Errorf("cannot decode JSON RPC response: %s", err)
 	}
 
 	if err := rpcResp.Error; err != nil {
-		err.endpoint = r.endpoint.String()
+		err.Endpoint = r.endpoint.String()
 		return err
 	}
 
@@ -0,0 +1,112 @@
+package rockside
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs digests on behalf of an Ethereum account, such as the
+// EIP712 hash of a forwarder meta-transaction.
+type Signer interface {
+	Address() common.Address
+
+	// SignHash signs the 32 bytes hash as is. The signature is 65 bytes
+	// [R || S || V] with V being 0 or 1, as returned by crypto.Sign.
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+type privateKeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewPrivateKeySigner returns a signer holding the private key in memory.
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) Signer {
+	return &privateKeySigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (s *privateKeySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *privateKeySigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+// NewKeystoreSigner decrypts the go-ethereum keystore file with the
+// passphrase and returns a signer for its key.
+func NewKeystoreSigner(path, passphrase string) (Signer, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt keystore %s: %w", path, err)
+	}
+	return NewPrivateKeySigner(key.PrivateKey), nil
+}
+
+// ErrPrefixedSignature is returned by the signer of a Rockside-hosted EOA
+// when Rockside signed the hash with the "\x19Ethereum Signed Message:\n32"
+// prefix: such a signature is not a signature of the hash itself, and
+// forwarders would reject it.
+var ErrPrefixedSignature = errors.New("hash signed with the Ethereum signed message prefix")
+
+type eoaSigner struct {
+	eoa     *EOA
+	address common.Address
+}
+
+// NewEOASigner returns a signer for an EOA hosted by Rockside. Hashes are
+// signed remotely with EOA.SignMessage. The returned signature is checked
+// to recover to the EOA address for the hash as is, so that SignHash never
+// returns a signature forwarders would reject: it fails with
+// ErrPrefixedSignature when Rockside signs with the Ethereum signed message
+// prefix, as eth_sign does.
+func NewEOASigner(c *Client, address common.Address) Signer {
+	return &eoaSigner{eoa: c.EOA, address: address}
+}
+
+func (s *eoaSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *eoaSigner) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	signed, err := s.eoa.SignMessageWithContext(ctx, s.address.String(), SignMessageRequest{Message: hexutil.Encode(hash)})
+	if err != nil {
+		return nil, err
+	}
+	sig, err := hexutil.Decode(signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature returned by Rockside: %w", err)
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length %d returned by Rockside", len(sig))
+	}
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	if recoversTo(hash, sig, s.address) {
+		return sig, nil
+	}
+	if recoversTo(accounts.TextHash(hash), sig, s.address) {
+		return nil, ErrPrefixedSignature
+	}
+	return nil, fmt.Errorf("signature returned by Rockside does not recover to %s", s.address.String())
+}
+
+func recoversTo(hash, sig []byte, address common.Address) bool {
+	pub, err := crypto.SigToPub(hash, sig)
+	return err == nil && crypto.PubkeyToAddress(*pub) == address
+}
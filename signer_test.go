@@ -0,0 +1,107 @@
+package rockside_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+func TestSigners(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	key, _ := crypto.GenerateKey()
+	dir, err := ioutil.TempDir("", "rockside-keystore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	account, err := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(key, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := account.URL.Path
+	if _, err := rockside.NewKeystoreSigner(keyFile, "wrong"); err == nil {
+		t.Fatal("expected error for wrong passphrase")
+	}
+	keystoreSigner, err := rockside.NewKeystoreSigner(keyFile, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eoa, err := client.EOA.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signers := []rockside.Signer{
+		rockside.NewPrivateKeySigner(key),
+		keystoreSigner,
+		rockside.NewEOASigner(client, common.HexToAddress(eoa.Address)),
+	}
+	for i, signer := range signers {
+		hash := crypto.Keccak256([]byte("message"))
+		sig, err := signer.SignHash(ctx, hash)
+		if err != nil {
+			t.Fatalf("case %d: %v", i+1, err)
+		}
+		if got, want := sig[64], byte(1); got > want {
+			t.Fatalf("case %d: got V %v, want 0 or 1", i+1, got)
+		}
+		pub, err := crypto.SigToPub(hash, sig)
+		if err != nil {
+			t.Fatalf("case %d: %v", i+1, err)
+		}
+		if got, want := crypto.PubkeyToAddress(*pub), signer.Address(); got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+
+		wallet := srv.NewWalletFor(t, signer.Address())
+		forwarder, smartWallet := wallet.Forwarder.String(), wallet.SmartWallet.String()
+		signature, err := client.Forwarder.SignTxParamsWithSignerWithContext(ctx, signer, forwarder, smartWallet, "0x", "")
+		if err != nil {
+			t.Fatalf("case %d: %v", i+1, err)
+		}
+		_, err = client.Forwarder.Relay(forwarder, rockside.RelayExecuteTxRequest{
+			Signature: signature,
+			Message:   rockside.RelayExecuteTxMessage{Signer: signer.Address().String(), To: smartWallet, Data: "0x", Nonce: "0"},
+		})
+		if err != nil {
+			t.Fatalf("case %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestEOASignerPrefixedSignature(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+	srv.PrefixedSignMessage = true
+	client := srv.Client()
+	ctx := context.Background()
+
+	eoa, err := client.EOA.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := rockside.NewEOASigner(client, common.HexToAddress(eoa.Address))
+
+	if _, err := signer.SignHash(ctx, crypto.Keccak256([]byte("message"))); !errors.Is(err, rockside.ErrPrefixedSignature) {
+		t.Fatalf("got %v, want %v", err, rockside.ErrPrefixedSignature)
+	}
+
+	wallet := srv.NewWalletFor(t, signer.Address())
+	_, err = client.Forwarder.SignTxParamsWithSignerWithContext(ctx, signer, wallet.Forwarder.String(), wallet.SmartWallet.String(), "0x", "")
+	if !errors.Is(err, rockside.ErrPrefixedSignature) {
+		t.Fatalf("got %v, want %v", err, rockside.ErrPrefixedSignature)
+	}
+}
@@ -0,0 +1,140 @@
+package rockside
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func (t *Transactions) Simulate(transaction Transaction) ([]byte, error) {
+	return t.SimulateWithContext(context.Background(), transaction)
+}
+
+// SimulateWithContext runs the transaction with eth_call from the smart
+// wallet, and returns the call result. When the transaction would revert,
+// the returned error is a *RevertError.
+func (t *Transactions) SimulateWithContext(ctx context.Context, transaction Transaction) ([]byte, error) {
+	ethTx, err := transaction.ToEthTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	msg := ethereum.CallMsg{
+		From:  common.HexToAddress(transaction.From),
+		To:    ethTx.To(),
+		Value: ethTx.Value(),
+		Data:  ethTx.Data(),
+		Gas:   ethTx.Gas(),
+	}
+	return t.client.RPCClient.EthCallWithContext(ctx, msg, nil)
+}
+
+func (e *Forwarder) SimulateRelay(forwarderAddress string, request RelayExecuteTxRequest) ([]byte, error) {
+	return e.SimulateRelayWithContext(context.Background(), forwarderAddress, request)
+}
+
+// SimulateRelayWithContext runs the forwarder call of the relay request with
+// eth_call from the relayer Rockside would use for the request speed. The
+// forwarder relay params (Forwarder.GetRelayParams) return no relayer, so it
+// is read from the direct relay params of the forwarder address
+// (Relay.GetParams, GET ethereum/<network>/relay/<forwarder>/params). Use
+// SimulateRelayFromWithContext when the relayer is already known.
+// When the relay would revert, the returned error is a *RevertError.
+func (e *Forwarder) SimulateRelayWithContext(ctx context.Context, forwarderAddress string, request RelayExecuteTxRequest) ([]byte, error) {
+	relayer, err := e.client.Relay.relayer(ctx, forwarderAddress, request.Speed)
+	if err != nil {
+		return nil, err
+	}
+	return e.SimulateRelayFromWithContext(ctx, forwarderAddress, relayer, request)
+}
+
+func (e *Forwarder) SimulateRelayFrom(forwarderAddress string, relayer common.Address, request RelayExecuteTxRequest) ([]byte, error) {
+	return e.SimulateRelayFromWithContext(context.Background(), forwarderAddress, relayer, request)
+}
+
+// SimulateRelayFromWithContext runs the forwarder call of the relay request
+// with eth_call from the given relayer.
+func (e *Forwarder) SimulateRelayFromWithContext(ctx context.Context, forwarderAddress string, relayer common.Address, request RelayExecuteTxRequest) ([]byte, error) {
+	data, err := encodeForward(request)
+	if err != nil {
+		return nil, err
+	}
+
+	forwarder := common.HexToAddress(forwarderAddress)
+	msg := ethereum.CallMsg{From: relayer, To: &forwarder, Data: data}
+	if request.Gas != "" {
+		gas, err := hexutil.DecodeUint64(request.Gas)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'gas' number: %w", err)
+		}
+		msg.Gas = gas
+	}
+	return e.client.RPCClient.EthCallWithContext(ctx, msg, nil)
+}
+
+func (e *Relay) Simulate(destination string, request RelayTx) ([]byte, error) {
+	return e.SimulateWithContext(context.Background(), destination, request)
+}
+
+// SimulateWithContext runs the direct relay with eth_call from the relayer
+// returned by the relay params. When the relay would revert, the returned
+// error is a *RevertError.
+func (e *Relay) SimulateWithContext(ctx context.Context, destination string, request RelayTx) ([]byte, error) {
+	data, err := hexutil.Decode(request.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'data' bytes: %w", err)
+	}
+
+	relayer, err := e.relayer(ctx, destination, request.Speed)
+	if err != nil {
+		return nil, err
+	}
+
+	to := common.HexToAddress(destination)
+	return e.client.RPCClient.EthCallWithContext(ctx, ethereum.CallMsg{From: relayer, To: &to, Data: data}, nil)
+}
+
+// relayer returns the relayer used for the given speed by the relay params of the destination.
+func (e *Relay) relayer(ctx context.Context, destination string, speed Speed) (common.Address, error) {
+	if speed == "" {
+		speed = SpeedStandard
+	}
+
+	params, err := e.GetParamsWithContext(ctx, destination)
+	if err != nil {
+		return common.Address{}, err
+	}
+	info, ok := params.Speeds[speed]
+	if !ok || !common.IsHexAddress(info.Relayer) {
+		return common.Address{}, fmt.Errorf("no relayer returned for speed '%s'", speed)
+	}
+	return common.HexToAddress(info.Relayer), nil
+}
+
+// encodeForward returns the calldata of the forwarder call executing the relay request.
+func encodeForward(request RelayExecuteTxRequest) ([]byte, error) {
+	signature, err := hexutil.Decode(request.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	nonce, ok := new(big.Int).SetString(request.Message.Nonce, 10)
+	if !ok {
+		return nil, fmt.Errorf("nonce is not a valid number [%s]", request.Message.Nonce)
+	}
+
+	parsed, err := forwarderABI.get()
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Pack("forward",
+		signature,
+		common.HexToAddress(request.Message.Signer),
+		common.HexToAddress(request.Message.To),
+		common.FromHex(request.Message.Data),
+		nonce,
+	)
+}
@@ -0,0 +1,176 @@
+package rockside_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+func TestSimulate(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	wallet := srv.NewWallet(t)
+	key, owner, forwarder, smartWallet := wallet.OwnerKey, wallet.Owner, wallet.Forwarder.String(), wallet.SmartWallet.String()
+	target := common.HexToAddress("0x618E5C42ECdc63aD84c95D714aFAdA52602Bbac3")
+
+	var calls []ethereum.CallMsg
+	revert := false
+	srv.CallHandler = func(msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+		calls = append(calls, msg)
+		if revert {
+			return nil, rocksidetest.Revert("not allowed")
+		}
+		return []byte{1}, nil
+	}
+
+	tx := rockside.Transaction{From: smartWallet, To: target.String(), Data: "0x1234"}
+
+	t.Run("transaction", func(t *testing.T) {
+		revert = false
+		ret, err := client.Transaction.SimulateWithContext(ctx, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ret, []byte{1}; !bytes.Equal(got, want) {
+			t.Fatalf("got %x, want %x", got, want)
+		}
+		call := calls[len(calls)-1]
+		if got, want := call.From, common.HexToAddress(smartWallet); got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := *call.To, target; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		revert = true
+		_, err = client.Transaction.SimulateWithContext(ctx, tx)
+		if !errors.Is(err, rockside.ErrReverted) {
+			t.Fatalf("expected reverted error, got %v", err)
+		}
+		var revertErr *rockside.RevertError
+		if !errors.As(err, &revertErr) {
+			t.Fatalf("expected *RevertError, got %T", err)
+		}
+		if got, want := revertErr.Reason, "not allowed"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("forwarder relay", func(t *testing.T) {
+		revert = false
+		params, err := client.Forwarder.GetRelayParams(forwarder, owner.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature, err := client.Forwarder.SignTxParams(common.Bytes2Hex(crypto.FromECDSA(key)), forwarder, owner.String(), target.String(), "0x1234", params.Nonce)
+		if err != nil {
+			t.Fatal(err)
+		}
+		request := rockside.RelayExecuteTxRequest{
+			Speed:     rockside.SpeedFast,
+			Signature: signature,
+			Message:   rockside.RelayExecuteTxMessage{Signer: owner.String(), To: target.String(), Data: "0x1234", Nonce: params.Nonce},
+		}
+
+		var paramsPaths []string
+		srv.AddHook(func(w http.ResponseWriter, r *http.Request) bool {
+			if strings.HasSuffix(r.URL.Path, "/params") {
+				paramsPaths = append(paramsPaths, r.URL.Path)
+			}
+			return false
+		})
+		srv.Relayer = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+		if _, err := client.Forwarder.SimulateRelayWithContext(ctx, forwarder, request); err != nil {
+			t.Fatal(err)
+		}
+		call := calls[len(calls)-1]
+		if got, want := call.From, srv.Relayer; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := strings.Join(paramsPaths, ","), "/ethereum/ropsten/relay/"+forwarder+"/params"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		relayer := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+		if _, err := client.Forwarder.SimulateRelayFromWithContext(ctx, forwarder, relayer, request); err != nil {
+			t.Fatal(err)
+		}
+		call = calls[len(calls)-1]
+		if got, want := call.From, relayer; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := len(paramsPaths), 1; got != want {
+			t.Fatalf("got %v relay params requests, want %v", got, want)
+		}
+		if got, want := *call.To, common.HexToAddress(forwarder); got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		forward := crypto.Keccak256([]byte("forward(bytes,address,address,bytes,uint256)"))[:4]
+		if got, want := call.Data[:4], forward; !bytes.Equal(got, want) {
+			t.Fatalf("got %x, want %x", got, want)
+		}
+	})
+
+	t.Run("direct relay", func(t *testing.T) {
+		revert = true
+		_, err := client.Relay.SimulateWithContext(ctx, target.String(), rockside.RelayTx{Data: "0x1234"})
+		if !errors.Is(err, rockside.ErrReverted) {
+			t.Fatalf("expected reverted error, got %v", err)
+		}
+		if got, want := calls[len(calls)-1].From, srv.Relayer; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("auto simulation", func(t *testing.T) {
+		client := srv.Client(rockside.WithSimulation())
+
+		revert = true
+		n := len(calls)
+		if _, err := client.Transaction.Send(tx); !errors.Is(err, rockside.ErrReverted) {
+			t.Fatalf("expected reverted error, got %v", err)
+		}
+		if got, want := len(calls), n+1; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		revert = false
+		sent, err := client.Transaction.Send(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.Transaction.Show(sent.TrackingID); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestDecodeRevertReason(t *testing.T) {
+	revert := rocksidetest.Revert("insufficient balance").(*rockside.RevertError)
+
+	reason, err := rockside.DecodeRevertReason(revert.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reason, "insufficient balance"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := rockside.DecodeRevertReason([]byte{1, 2, 3, 4}); err == nil {
+		t.Fatal("expected error for unknown selector")
+	}
+}
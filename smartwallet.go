@@ -1,6 +1,7 @@
 package rockside
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -13,25 +14,42 @@ const (
 type SmartWallets endpoint
 
 func (i *SmartWallets) Create(account, forwarder string) (ContractCreationResponse, error) {
+	return i.CreateWithContext(context.Background(), account, forwarder)
+}
+
+func (i *SmartWallets) CreateWithContext(ctx context.Context, account, forwarder string) (ContractCreationResponse, error) {
 	var result ContractCreationResponse
 
-	path := fmt.Sprintf("ethereum/%s/smartwallets", i.client.network)
+	path := fmt.Sprintf("ethereum/%s/smartwallets", i.client.networkPath)
 	req := struct {
 		Account   string `json:"account"`
 		Forwarder string `json:"forwarder"`
 	}{account, forwarder}
-	if _, err := i.client.post(path, req, &result); err != nil {
+	if _, err := i.client.post(ctx, "SmartWallets.Create", path, req, &result); err != nil {
 		return result, err
 	}
 
 	return result, nil
 }
 
+// CreateForSigner creates a smart wallet owned by the signer address.
+func (i *SmartWallets) CreateForSigner(signer Signer, forwarder string) (ContractCreationResponse, error) {
+	return i.CreateForSignerWithContext(context.Background(), signer, forwarder)
+}
+
+func (i *SmartWallets) CreateForSignerWithContext(ctx context.Context, signer Signer, forwarder string) (ContractCreationResponse, error) {
+	return i.CreateWithContext(ctx, signer.Address().String(), forwarder)
+}
+
 func (i *SmartWallets) List() ([]string, error) {
+	return i.ListWithContext(context.Background())
+}
+
+func (i *SmartWallets) ListWithContext(ctx context.Context) ([]string, error) {
 	var result []string
 
-	path := fmt.Sprintf("ethereum/%s/smartwallets", i.client.network)
-	if _, err := i.client.get(path, nil, &result); err != nil {
+	path := fmt.Sprintf("ethereum/%s/smartwallets", i.client.networkPath)
+	if _, err := i.client.get(ctx, "SmartWallets.List", path, nil, &result); err != nil {
 		return result, err
 	}
 
@@ -39,7 +57,11 @@ func (i *SmartWallets) List() ([]string, error) {
 }
 
 func (i *SmartWallets) Exists(smartWalletAddr common.Address) (bool, error) {
-	all, err := i.client.SmartWallets.List()
+	return i.ExistsWithContext(context.Background(), smartWalletAddr)
+}
+
+func (i *SmartWallets) ExistsWithContext(ctx context.Context, smartWalletAddr common.Address) (bool, error) {
+	all, err := i.ListWithContext(ctx)
 	if err != nil {
 		return false, err
 	}
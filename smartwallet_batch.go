@@ -0,0 +1,124 @@
+package rockside
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BatchCall is a call executed by a smart wallet batch. Its fields match
+// the SmartWallet.Call struct of the contract.
+type BatchCall struct {
+	To    common.Address
+	Value *big.Int
+	Data  []byte
+}
+
+// SmartWalletBatch builds a call to the smart wallet batch function, to
+// execute several calls atomically in one transaction.
+//
+//	batch := rockside.NewSmartWalletBatch(smartWallet).
+//		Add(token, nil, approveCalldata).
+//		Add(exchange, nil, swapCalldata)
+//	resp, err := batch.Send(ctx, client)
+type SmartWalletBatch struct {
+	smartWallet common.Address
+	calls       []BatchCall
+}
+
+func NewSmartWalletBatch(smartWallet common.Address) *SmartWalletBatch {
+	return &SmartWalletBatch{smartWallet: smartWallet}
+}
+
+// Add appends a call to the batch. A nil value sends no ether.
+func (b *SmartWalletBatch) Add(to common.Address, value *big.Int, data []byte) *SmartWalletBatch {
+	if value == nil {
+		value = new(big.Int)
+	}
+	b.calls = append(b.calls, BatchCall{To: to, Value: value, Data: data})
+	return b
+}
+
+func (b *SmartWalletBatch) Calls() []BatchCall {
+	return append([]BatchCall(nil), b.calls...)
+}
+
+// Encode returns the calldata of the smart wallet batch function.
+func (b *SmartWalletBatch) Encode() ([]byte, error) {
+	if len(b.calls) == 0 {
+		return nil, errors.New("empty smart wallet batch")
+	}
+	for _, c := range b.calls {
+		if c.Value.Sign() < 0 {
+			return nil, errors.New("invalid batch call value: must be positive")
+		}
+	}
+
+	parsed, err := smartWalletABI.get()
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Pack("batch", b.calls)
+}
+
+// Transaction returns the transaction calling the batch function, sent from the smart wallet to itself.
+func (b *SmartWalletBatch) Transaction() (Transaction, error) {
+	data, err := b.Encode()
+	if err != nil {
+		return Transaction{}, err
+	}
+	return NewTransactionBuilder(b.smartWallet).To(b.smartWallet).Data(data).Build()
+}
+
+// Send sends the batch from the smart wallet with Transactions.Send.
+func (b *SmartWalletBatch) Send(c *Client) (ContractCreationResponse, error) {
+	return b.SendWithContext(context.Background(), c)
+}
+
+func (b *SmartWalletBatch) SendWithContext(ctx context.Context, c *Client) (ContractCreationResponse, error) {
+	tx, err := b.Transaction()
+	if err != nil {
+		return ContractCreationResponse{}, err
+	}
+	return c.Transaction.SendWithContext(ctx, tx)
+}
+
+// Relay signs the batch with the signer, an owner of the smart wallet, and
+// relays it through the forwarder, with the gas price limit given by the policy.
+func (b *SmartWalletBatch) Relay(c *Client, forwarder string, signer Signer, policy GasPricePolicy) (RelayTxResponse, error) {
+	return b.RelayWithContext(context.Background(), c, forwarder, signer, policy)
+}
+
+func (b *SmartWalletBatch) RelayWithContext(ctx context.Context, c *Client, forwarder string, signer Signer, policy GasPricePolicy) (RelayTxResponse, error) {
+	data, err := b.Encode()
+	if err != nil {
+		return RelayTxResponse{}, err
+	}
+
+	owner := signer.Address()
+	params, err := c.Forwarder.GetRelayParamsWithContext(ctx, forwarder, owner.String())
+	if err != nil {
+		return RelayTxResponse{}, err
+	}
+
+	message := RelayExecuteTxMessage{
+		Signer: owner.String(),
+		To:     b.smartWallet.String(),
+		Data:   hexutil.Encode(data),
+		Nonce:  params.Nonce,
+	}
+	signature, err := c.Forwarder.SignTxParamsWithSignerWithContext(ctx, signer, forwarder, message.To, message.Data, message.Nonce)
+	if err != nil {
+		return RelayTxResponse{}, err
+	}
+
+	request := RelayExecuteTxRequest{Message: message, Signature: signature}
+	if err := policy.Apply(&request, params.GasPrices); err != nil {
+		return RelayTxResponse{}, err
+	}
+
+	return c.Forwarder.RelayWithContext(ctx, forwarder, request)
+}
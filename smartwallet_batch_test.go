@@ -0,0 +1,78 @@
+package rockside_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+func TestSmartWalletBatch(t *testing.T) {
+	smartWallet := common.HexToAddress("0x268ba693540A7176ae5d3ba9256A18efbe0A63FF")
+
+	if _, err := rockside.NewSmartWalletBatch(smartWallet).Encode(); err == nil {
+		t.Fatal("expected error for empty batch")
+	}
+
+	batch := rockside.NewSmartWalletBatch(smartWallet).Add(common.Address{1}, big.NewInt(1), []byte{1, 2})
+	data, err := batch.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "b780c362" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000100000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000060" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0102000000000000000000000000000000000000000000000000000000000000"
+	if got, want := common.Bytes2Hex(data), expected; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := data[:4], crypto.Keccak256([]byte("batch((address,uint256,bytes)[])"))[:4]; !bytes.Equal(got, want) {
+		t.Fatalf("got %x, want %x", got, want)
+	}
+
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	wallet := srv.NewWallet(t)
+
+	batch = rockside.NewSmartWalletBatch(wallet.SmartWallet).
+		Add(common.Address{1}, nil, []byte{1}).
+		Add(common.Address{2}, nil, []byte{2})
+	data, _ = batch.Encode()
+
+	sent, err := batch.Send(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := client.Transaction.Show(sent.TrackingID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := status.Data, data; !bytes.Equal(got, want) {
+		t.Fatalf("got %x, want %x", got, want)
+	}
+
+	relayed, err := batch.RelayWithContext(ctx, client, wallet.Forwarder.String(), rockside.NewPrivateKeySigner(wallet.OwnerKey), rockside.GasPricePolicy{Speed: rockside.SpeedFast})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err = client.Transaction.Show(relayed.TrackingID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := *status.To, wallet.Forwarder; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,194 @@
+package rockside
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrNoRelayedExecute is returned by RelayedExecuteSuccess when the receipt
+// holds no RelayedExecute event.
+var ErrNoRelayedExecute = errors.New("no RelayedExecute event")
+
+// SmartWalletEvent is an event emitted by a smart wallet contract. It is one
+// of *ExecutedEvent, *RelayedExecuteEvent, *ReceivedEvent, *DataChangedEvent,
+// *UpdateOwnersEvent or *DeployedEvent.
+type SmartWalletEvent interface {
+	// EventName is the name of the event in SmartWalletABI.
+	EventName() string
+	// Log is the log the event was decoded from.
+	Log() types.Log
+}
+
+// ExecutedEvent is emitted for each call executed by a smart wallet.
+type ExecutedEvent struct {
+	Destination common.Address
+	Value       *big.Int
+	Data        []byte
+	Raw         types.Log
+}
+
+// RelayedExecuteEvent is emitted when a smart wallet executes a relayed
+// call. Success is false when the inner call reverted, even though the
+// transaction itself succeeded.
+type RelayedExecuteEvent struct {
+	Success bool
+	Raw     types.Log
+}
+
+// ReceivedEvent is emitted when a smart wallet receives ether.
+type ReceivedEvent struct {
+	Sender common.Address
+	Value  *big.Int
+	Raw    types.Log
+}
+
+// DataChangedEvent is emitted when a smart wallet data key is set.
+type DataChangedEvent struct {
+	Key   common.Hash
+	Value []byte
+	Raw   types.Log
+}
+
+// UpdateOwnersEvent is emitted when a smart wallet owner is added (Value is
+// true) or removed.
+type UpdateOwnersEvent struct {
+	Account common.Address
+	Value   bool
+	Raw     types.Log
+}
+
+// DeployedEvent is emitted when a smart wallet deploys a contract.
+type DeployedEvent struct {
+	Value    *big.Int
+	Salt     [32]byte
+	InitCode []byte
+	Raw      types.Log
+}
+
+func (*ExecutedEvent) EventName() string       { return "Executed" }
+func (*RelayedExecuteEvent) EventName() string { return "RelayedExecute" }
+func (*ReceivedEvent) EventName() string       { return "Received" }
+func (*DataChangedEvent) EventName() string    { return "DataChanged" }
+func (*UpdateOwnersEvent) EventName() string   { return "UpdateOwners" }
+func (*DeployedEvent) EventName() string       { return "Deployed" }
+
+func (e *ExecutedEvent) Log() types.Log       { return e.Raw }
+func (e *RelayedExecuteEvent) Log() types.Log { return e.Raw }
+func (e *ReceivedEvent) Log() types.Log       { return e.Raw }
+func (e *DataChangedEvent) Log() types.Log    { return e.Raw }
+func (e *UpdateOwnersEvent) Log() types.Log   { return e.Raw }
+func (e *DeployedEvent) Log() types.Log       { return e.Raw }
+
+// DecodeSmartWalletReceipt returns the smart wallet events of a receipt.
+// See DecodeSmartWalletLogs.
+func DecodeSmartWalletReceipt(receipt *types.Receipt) ([]SmartWalletEvent, error) {
+	if receipt == nil {
+		return nil, errors.New("nil receipt")
+	}
+	logs := make([]types.Log, 0, len(receipt.Logs))
+	for _, l := range receipt.Logs {
+		if l != nil {
+			logs = append(logs, *l)
+		}
+	}
+	return DecodeSmartWalletLogs(logs)
+}
+
+// DecodeSmartWalletLogs returns the smart wallet events found in the logs,
+// in order. Logs of other events are skipped. Use the address of the log
+// to keep the events of a given smart wallet.
+func DecodeSmartWalletLogs(logs []types.Log) ([]SmartWalletEvent, error) {
+	var events []SmartWalletEvent
+	for _, l := range logs {
+		ev, err := DecodeSmartWalletLog(l)
+		if err != nil {
+			return nil, err
+		}
+		if ev != nil {
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
+
+// DecodeSmartWalletLog decodes a smart wallet event. It returns a nil event
+// and no error when the log is not a smart wallet event, including when its
+// topics or data do not match the arguments of the event.
+func DecodeSmartWalletLog(l types.Log) (SmartWalletEvent, error) {
+	if len(l.Topics) == 0 {
+		return nil, nil
+	}
+	parsed, err := smartWalletABI.get()
+	if err != nil {
+		return nil, err
+	}
+	event, err := parsed.EventByID(l.Topics[0])
+	if err != nil {
+		return nil, nil
+	}
+
+	indexed := 0
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed++
+		}
+	}
+	// Another contract may emit an event with the same signature but other
+	// indexed arguments, or other data.
+	if len(l.Topics) != indexed+1 {
+		return nil, nil
+	}
+	values, err := event.Inputs.UnpackValues(l.Data)
+	if err != nil {
+		return nil, nil
+	}
+
+	switch event.Name {
+	case "Executed":
+		return &ExecutedEvent{
+			Destination: values[0].(common.Address),
+			Value:       values[1].(*big.Int),
+			Data:        values[2].([]byte),
+			Raw:         l,
+		}, nil
+	case "RelayedExecute":
+		return &RelayedExecuteEvent{Success: values[0].(bool), Raw: l}, nil
+	case "Received":
+		return &ReceivedEvent{
+			Sender: common.BytesToAddress(l.Topics[1].Bytes()),
+			Value:  values[0].(*big.Int),
+			Raw:    l,
+		}, nil
+	case "DataChanged":
+		return &DataChangedEvent{Key: l.Topics[1], Value: values[0].([]byte), Raw: l}, nil
+	case "UpdateOwners":
+		return &UpdateOwnersEvent{Account: values[0].(common.Address), Value: values[1].(bool), Raw: l}, nil
+	case "Deployed":
+		return &DeployedEvent{
+			Value:    values[0].(*big.Int),
+			Salt:     values[1].([32]byte),
+			InitCode: values[2].([]byte),
+			Raw:      l,
+		}, nil
+	}
+	return nil, nil
+}
+
+// RelayedExecuteSuccess tells whether the inner call of a relayed
+// transaction succeeded, from the RelayedExecute event of its receipt. It
+// returns ErrNoRelayedExecute when the receipt holds no such event.
+func RelayedExecuteSuccess(receipt *types.Receipt) (bool, error) {
+	events, err := DecodeSmartWalletReceipt(receipt)
+	if err != nil {
+		return false, err
+	}
+	for _, ev := range events {
+		if relayed, ok := ev.(*RelayedExecuteEvent); ok {
+			return relayed.Success, nil
+		}
+	}
+	return false, ErrNoRelayedExecute
+}
@@ -0,0 +1,134 @@
+package rockside_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+func smartWalletLog(t *testing.T, address common.Address, name string, topics []common.Hash, args ...interface{}) *types.Log {
+	parsed, err := abi.JSON(strings.NewReader(rockside.SmartWalletABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := parsed.Events[name]
+	data, err := event.Inputs.NonIndexed().Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &types.Log{Address: address, Topics: append([]common.Hash{event.ID()}, topics...), Data: data}
+}
+
+func TestDecodeSmartWalletEvents(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	smartWallet := srv.NewWallet(t).SmartWallet
+	sender := common.HexToAddress("0x2a7D2b1Aa8B6dF3b5A3b8c4E6f0e1F2a3B4c5D6e")
+	key := common.HexToHash("0x01")
+	var salt [32]byte
+	salt[31] = 7
+
+	sent, err := client.Transaction.Send(rockside.Transaction{From: smartWallet.String(), To: smartWallet.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Mine(sent.TrackingID)
+	err = srv.AddLogs(sent.TrackingID,
+		smartWalletLog(t, smartWallet, "Executed", nil, sender, big.NewInt(1), []byte{1, 2}),
+		&types.Log{Address: sender, Topics: []common.Hash{common.HexToHash("0xdead")}},
+		smartWalletLog(t, smartWallet, "Received", []common.Hash{common.BytesToHash(sender.Bytes())}, big.NewInt(2)),
+		smartWalletLog(t, smartWallet, "DataChanged", []common.Hash{key}, []byte{3}),
+		smartWalletLog(t, smartWallet, "UpdateOwners", nil, sender, true),
+		smartWalletLog(t, smartWallet, "Deployed", nil, big.NewInt(4), salt, []byte{5}),
+		smartWalletLog(t, smartWallet, "RelayedExecute", nil, false),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := client.RPCClient.TransactionReceipt(ctx, common.HexToHash(sent.TransactionHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := rockside.DecodeSmartWalletReceipt(receipt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, ev := range events {
+		names = append(names, ev.EventName())
+		if got, want := ev.Log().Address, smartWallet; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	if got, want := strings.Join(names, ","), "Executed,Received,DataChanged,UpdateOwners,Deployed,RelayedExecute"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	executed := events[0].(*rockside.ExecutedEvent)
+	if executed.Destination != sender || executed.Value.Int64() != 1 || !bytes.Equal(executed.Data, []byte{1, 2}) {
+		t.Fatalf("unexpected Executed event %+v", executed)
+	}
+	received := events[1].(*rockside.ReceivedEvent)
+	if received.Sender != sender || received.Value.Int64() != 2 {
+		t.Fatalf("unexpected Received event %+v", received)
+	}
+	changed := events[2].(*rockside.DataChangedEvent)
+	if changed.Key != key || !bytes.Equal(changed.Value, []byte{3}) {
+		t.Fatalf("unexpected DataChanged event %+v", changed)
+	}
+	owners := events[3].(*rockside.UpdateOwnersEvent)
+	if owners.Account != sender || !owners.Value {
+		t.Fatalf("unexpected UpdateOwners event %+v", owners)
+	}
+	deployed := events[4].(*rockside.DeployedEvent)
+	if deployed.Value.Int64() != 4 || deployed.Salt != salt || !bytes.Equal(deployed.InitCode, []byte{5}) {
+		t.Fatalf("unexpected Deployed event %+v", deployed)
+	}
+
+	success, err := rockside.RelayedExecuteSuccess(receipt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if success {
+		t.Fatal("expected failed relayed call")
+	}
+	if _, err := rockside.RelayedExecuteSuccess(&types.Receipt{}); !errors.Is(err, rockside.ErrNoRelayedExecute) {
+		t.Fatalf("got %v, want %v", err, rockside.ErrNoRelayedExecute)
+	}
+
+	// Received(address,uint256) emitted by another contract with a non
+	// indexed sender, or Executed with other data, are not smart wallet
+	// events.
+	foreign := smartWalletLog(t, sender, "Received", nil, big.NewInt(2))
+	foreign.Data = append(common.LeftPadBytes(sender.Bytes(), 32), foreign.Data...)
+	undecodable := smartWalletLog(t, sender, "Executed", nil, sender, big.NewInt(1), []byte{1, 2})
+	undecodable.Data = []byte{1, 2, 3}
+	relayed := smartWalletLog(t, smartWallet, "RelayedExecute", nil, true)
+	events, err = rockside.DecodeSmartWalletLogs([]types.Log{*foreign, *undecodable, *relayed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(events), 1; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	success, err = rockside.RelayedExecuteSuccess(&types.Receipt{Logs: []*types.Log{foreign, undecodable, relayed}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !success {
+		t.Fatal("expected successful relayed call")
+	}
+}
@@ -0,0 +1,102 @@
+package rockside
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Speed is the relay speed, which determines the gas price used by Rockside.
+type Speed string
+
+const (
+	SpeedFastest  Speed = "fastest"
+	SpeedFast     Speed = "fast"
+	SpeedStandard Speed = "standard"
+	SpeedSafeLow  Speed = "safelow"
+)
+
+// Speeds returns the known speeds, from the fastest to the slowest.
+func Speeds() []Speed {
+	return []Speed{SpeedFastest, SpeedFast, SpeedStandard, SpeedSafeLow}
+}
+
+// Validate returns an error when the speed is not a known speed.
+func (s Speed) Validate() error {
+	for _, known := range Speeds() {
+		if s == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid speed '%s'. Expecting one of: %s", s, Speeds())
+}
+
+// GasPricePolicy derives the gas price limit of a relayed transaction from
+// the gas prices returned by Rockside relay params.
+type GasPricePolicy struct {
+	// Speed of the transaction (default SpeedStandard).
+	Speed Speed
+
+	// Fallback is the speed used when no gas price is returned for Speed.
+	Fallback Speed
+
+	// Multiplier is applied to the gas price of the speed to leave room for
+	// price variations before the transaction is sent (default 1).
+	Multiplier float64
+
+	// Cap is the maximum gas price limit, in wei. No cap when nil.
+	Cap *big.Int
+}
+
+// GasPriceLimit returns the speed and the gas price limit to use given the
+// gas prices, in wei per speed.
+func (p GasPricePolicy) GasPriceLimit(gasPrices map[Speed]string) (Speed, *big.Int, error) {
+	speed := p.Speed
+	if speed == "" {
+		speed = SpeedStandard
+	}
+	if err := speed.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	price, ok := gasPrices[speed]
+	if !ok && p.Fallback != "" {
+		if err := p.Fallback.Validate(); err != nil {
+			return "", nil, err
+		}
+		speed = p.Fallback
+		price, ok = gasPrices[speed]
+	}
+	if !ok {
+		return "", nil, fmt.Errorf("no gas price returned for speed '%s'", speed)
+	}
+
+	gasPrice, ok := new(big.Int).SetString(strings.TrimSpace(price), 10)
+	if !ok || gasPrice.Sign() < 0 {
+		return "", nil, fmt.Errorf("invalid gas price '%s' for speed '%s'", price, speed)
+	}
+
+	limit := gasPrice
+	if p.Multiplier > 0 && p.Multiplier != 1 {
+		limit, _ = new(big.Float).Mul(new(big.Float).SetInt(gasPrice), big.NewFloat(p.Multiplier)).Int(nil)
+	}
+	if p.Cap != nil && limit.Cmp(p.Cap) > 0 {
+		if gasPrice.Cmp(p.Cap) > 0 {
+			return "", nil, fmt.Errorf("gas price %s for speed '%s' is above cap %s", gasPrice, speed, p.Cap)
+		}
+		limit = new(big.Int).Set(p.Cap)
+	}
+
+	return speed, limit, nil
+}
+
+// Apply sets the speed and the gas price limit of the relay request.
+func (p GasPricePolicy) Apply(request *RelayExecuteTxRequest, gasPrices map[Speed]string) error {
+	speed, limit, err := p.GasPriceLimit(gasPrices)
+	if err != nil {
+		return err
+	}
+	request.Speed = speed
+	request.GasPriceLimit = limit.String()
+	return nil
+}
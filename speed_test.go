@@ -0,0 +1,71 @@
+package rockside
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestSpeedValidate(t *testing.T) {
+	for _, s := range Speeds() {
+		if err := s.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Speed("slow").Validate(); err == nil || !strings.Contains(err.Error(), "invalid speed 'slow'") {
+		t.Fatalf("expected invalid speed error, got %v", err)
+	}
+
+	_, err := new(Forwarder).Relay("0x268ba693540A7176ae5d3ba9256A18efbe0A63FF", RelayExecuteTxRequest{Speed: "slow"})
+	if err == nil || !strings.Contains(err.Error(), "invalid speed") {
+		t.Fatalf("expected invalid speed error, got %v", err)
+	}
+}
+
+func TestGasPricePolicy(t *testing.T) {
+	prices := map[Speed]string{
+		SpeedFast:     "20000000000",
+		SpeedStandard: "10000000000",
+	}
+	tests := []struct {
+		policy      GasPricePolicy
+		speed       Speed
+		limit       string
+		errContains string
+	}{
+		{policy: GasPricePolicy{}, speed: SpeedStandard, limit: "10000000000"},
+		{policy: GasPricePolicy{Speed: SpeedFast, Multiplier: 1.5}, speed: SpeedFast, limit: "30000000000"},
+		{policy: GasPricePolicy{Multiplier: 2, Cap: big.NewInt(15000000000)}, speed: SpeedStandard, limit: "15000000000"},
+		{policy: GasPricePolicy{Cap: big.NewInt(5000000000)}, errContains: "above cap"},
+		{policy: GasPricePolicy{Speed: SpeedFastest, Fallback: SpeedFast}, speed: SpeedFast, limit: "20000000000"},
+		{policy: GasPricePolicy{Speed: SpeedFastest}, errContains: "no gas price"},
+		{policy: GasPricePolicy{Speed: "slow"}, errContains: "invalid speed"},
+	}
+
+	for i, test := range tests {
+		speed, limit, err := test.policy.GasPriceLimit(prices)
+		if test.errContains != "" {
+			if err == nil || !strings.Contains(err.Error(), test.errContains) {
+				t.Fatalf("case %d: expecting error %q to contains %q", i+1, err, test.errContains)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %d: unexpected error %s", i+1, err)
+		}
+		if got, want := speed, test.speed; got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+		if got, want := limit.String(), test.limit; got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+	}
+
+	var request RelayExecuteTxRequest
+	if err := (GasPricePolicy{Speed: SpeedFast}).Apply(&request, prices); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := request.GasPriceLimit, "20000000000"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -1,16 +1,26 @@
 package rockside
 
+import "context"
+
 type Tokens endpoint
 
 func (i *Tokens) Create(domain string, contracts []string) (tokenResponse, error) {
-	return i.createRequest(domain, "", contracts)
+	return i.CreateWithContext(context.Background(), domain, contracts)
+}
+
+func (i *Tokens) CreateWithContext(ctx context.Context, domain string, contracts []string) (tokenResponse, error) {
+	return i.createRequest(ctx, domain, "", contracts)
 }
 
 func (i *Tokens) CreateForEndUser(domain string, endUserID string, contracts []string) (tokenResponse, error) {
-	return i.createRequest(domain, endUserID, contracts)
+	return i.CreateForEndUserWithContext(context.Background(), domain, endUserID, contracts)
+}
+
+func (i *Tokens) CreateForEndUserWithContext(ctx context.Context, domain string, endUserID string, contracts []string) (tokenResponse, error) {
+	return i.createRequest(ctx, domain, endUserID, contracts)
 }
 
-func (i *Tokens) createRequest(origin string, endUserID string, contracts []string) (tokenResponse, error) {
+func (i *Tokens) createRequest(ctx context.Context, origin string, endUserID string, contracts []string) (tokenResponse, error) {
 	var result tokenResponse
 
 	req := struct {
@@ -19,7 +29,7 @@ func (i *Tokens) createRequest(origin string, endUserID string, contracts []stri
 		Contracts []string `json:"contracts"`
 	}{Origin: origin, EndUserID: endUserID, Contracts: contracts}
 
-	if _, err := i.client.post("/tokens", req, &result); err != nil {
+	if _, err := i.client.post(ctx, "Tokens.Create", "/tokens", req, &result); err != nil {
 		return result, err
 	}
 
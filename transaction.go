@@ -1,11 +1,14 @@
 package rockside
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type Transactions endpoint
@@ -21,25 +24,39 @@ type Transaction struct {
 }
 
 func (t *Transactions) Send(transaction Transaction) (ContractCreationResponse, error) {
+	return t.SendWithContext(context.Background(), transaction)
+}
+
+func (t *Transactions) SendWithContext(ctx context.Context, transaction Transaction) (ContractCreationResponse, error) {
 	var result ContractCreationResponse
 
 	if err := transaction.validateFields(); err != nil {
 		return result, err
 	}
 
-	path := fmt.Sprintf("ethereum/%s/transaction", t.client.network)
-	if _, err := t.client.post(path, transaction, &result); err != nil {
+	if t.client.simulate {
+		if _, err := t.SimulateWithContext(ctx, transaction); err != nil {
+			return result, fmt.Errorf("simulate transaction: %w", err)
+		}
+	}
+
+	path := fmt.Sprintf("ethereum/%s/transaction", t.client.networkPath)
+	if _, err := t.client.post(ctx, "Transactions.Send", path, transaction, &result); err != nil {
 		return result, err
 	}
 
 	return result, nil
 }
 
-func (t *Transactions) Show(txHashOrTrackingID string) (interface{}, error) {
-	var result interface{}
+func (t *Transactions) Show(txHashOrTrackingID string) (TransactionStatus, error) {
+	return t.ShowWithContext(context.Background(), txHashOrTrackingID)
+}
+
+func (t *Transactions) ShowWithContext(ctx context.Context, txHashOrTrackingID string) (TransactionStatus, error) {
+	var result TransactionStatus
 
-	path := fmt.Sprintf("ethereum/%s/transactions/%s", t.client.network, txHashOrTrackingID)
-	if _, err := t.client.get(path, nil, &result); err != nil {
+	path := fmt.Sprintf("ethereum/%s/transactions/%s", t.client.networkPath, txHashOrTrackingID)
+	if _, err := t.client.get(ctx, "Transactions.Show", path, nil, &result); err != nil {
 		return result, err
 	}
 
@@ -64,5 +81,142 @@ func (t Transaction) validateFields() error {
 			return fmt.Errorf("invalid 'value' number: %w", err)
 		}
 	}
+	if len(t.Nonce) > 0 {
+		if _, err := hexutil.DecodeUint64(t.Nonce); err != nil {
+			return fmt.Errorf("invalid 'nonce' number: %w", err)
+		}
+	}
+	if len(t.Gas) > 0 {
+		if _, err := hexutil.DecodeUint64(t.Gas); err != nil {
+			return fmt.Errorf("invalid 'gas' number: %w", err)
+		}
+	}
+	if len(t.GasPrice) > 0 {
+		if _, err := hexutil.DecodeBig(t.GasPrice); err != nil {
+			return fmt.Errorf("invalid 'gasprice' number: %w", err)
+		}
+	}
 	return nil
 }
+
+// ToEthTransaction converts the transaction to an unsigned go-ethereum
+// transaction. Empty fields are converted to zero values.
+func (t Transaction) ToEthTransaction() (*types.Transaction, error) {
+	if err := t.validateFields(); err != nil {
+		return nil, err
+	}
+
+	var (
+		nonce, gas      uint64
+		value, gasPrice = new(big.Int), new(big.Int)
+		data            []byte
+	)
+	if t.Nonce != "" {
+		nonce, _ = hexutil.DecodeUint64(t.Nonce)
+	}
+	if t.Gas != "" {
+		gas, _ = hexutil.DecodeUint64(t.Gas)
+	}
+	if t.Value != "" {
+		value, _ = hexutil.DecodeBig(t.Value)
+	}
+	if t.GasPrice != "" {
+		gasPrice, _ = hexutil.DecodeBig(t.GasPrice)
+	}
+	if t.Data != "" {
+		data, _ = hexutil.Decode(t.Data)
+	}
+
+	if t.To == "" {
+		return types.NewContractCreation(nonce, value, gas, gasPrice, data), nil
+	}
+	return types.NewTransaction(nonce, common.HexToAddress(t.To), value, gas, gasPrice, data), nil
+}
+
+// TransactionFromEth converts a go-ethereum transaction sent from the given address.
+func TransactionFromEth(from common.Address, tx *types.Transaction) Transaction {
+	t := Transaction{
+		From:     from.String(),
+		Value:    hexutil.EncodeBig(tx.Value()),
+		Nonce:    hexutil.EncodeUint64(tx.Nonce()),
+		Gas:      hexutil.EncodeUint64(tx.Gas()),
+		GasPrice: hexutil.EncodeBig(tx.GasPrice()),
+	}
+	if tx.To() != nil {
+		t.To = tx.To().String()
+	}
+	if len(tx.Data()) > 0 {
+		t.Data = hexutil.Encode(tx.Data())
+	}
+	return t
+}
+
+// TransactionBuilder builds a validated Transaction from typed values.
+//
+//	tx, err := rockside.NewTransactionBuilder(smartWallet).To(contract).Data(calldata).Build()
+type TransactionBuilder struct {
+	tx  Transaction
+	err error
+}
+
+// NewTransactionBuilder starts a transaction sent from the given address.
+func NewTransactionBuilder(from common.Address) *TransactionBuilder {
+	return &TransactionBuilder{tx: Transaction{From: from.String()}}
+}
+
+func (b *TransactionBuilder) To(to common.Address) *TransactionBuilder {
+	b.tx.To = to.String()
+	return b
+}
+
+func (b *TransactionBuilder) Value(value *big.Int) *TransactionBuilder {
+	if value == nil || value.Sign() < 0 {
+		b.fail(errors.New("invalid 'value' number: must be positive"))
+		return b
+	}
+	b.tx.Value = hexutil.EncodeBig(value)
+	return b
+}
+
+func (b *TransactionBuilder) Data(data []byte) *TransactionBuilder {
+	if len(data) > 0 {
+		b.tx.Data = hexutil.Encode(data)
+	}
+	return b
+}
+
+func (b *TransactionBuilder) Nonce(nonce uint64) *TransactionBuilder {
+	b.tx.Nonce = hexutil.EncodeUint64(nonce)
+	return b
+}
+
+func (b *TransactionBuilder) Gas(gas uint64) *TransactionBuilder {
+	b.tx.Gas = hexutil.EncodeUint64(gas)
+	return b
+}
+
+func (b *TransactionBuilder) GasPrice(gasPrice *big.Int) *TransactionBuilder {
+	if gasPrice == nil || gasPrice.Sign() < 0 {
+		b.fail(errors.New("invalid 'gasprice' number: must be positive"))
+		return b
+	}
+	b.tx.GasPrice = hexutil.EncodeBig(gasPrice)
+	return b
+}
+
+func (b *TransactionBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Build returns the transaction, or the first invalid value given to the builder.
+func (b *TransactionBuilder) Build() (Transaction, error) {
+	if b.err != nil {
+		return Transaction{}, b.err
+	}
+	if err := b.tx.validateFields(); err != nil {
+		return Transaction{}, err
+	}
+	return b.tx, nil
+}
@@ -0,0 +1,157 @@
+package rockside
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxStatus is the status of a transaction tracked by Rockside.
+type TxStatus string
+
+const (
+	TxStatusPending  TxStatus = "pending"
+	TxStatusMined    TxStatus = "mined"
+	TxStatusFailed   TxStatus = "failed"
+	TxStatusReplaced TxStatus = "replaced"
+)
+
+// TransactionStatus is a transaction as tracked by Rockside, returned by Transactions.Show.
+type TransactionStatus struct {
+	Status     TxStatus
+	TrackingID string
+
+	// TransactionHash is the hash of the transaction currently tracked.
+	// PreviousTransactionHashes are the hashes it replaced, when Rockside
+	// sped the transaction up.
+	TransactionHash           common.Hash
+	PreviousTransactionHashes []common.Hash
+
+	From     common.Address
+	To       *common.Address // nil for contract creations
+	Data     []byte
+	Value    *big.Int
+	Gas      uint64
+	GasPrice *big.Int
+
+	// BlockNumber and Receipt are set once the transaction is mined.
+	BlockNumber *big.Int
+	Receipt     *types.Receipt
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Raw holds every field returned by Rockside, including the ones not
+	// mapped above.
+	Raw map[string]json.RawMessage
+}
+
+// AllTransactionHashes returns the current transaction hash followed by the
+// hashes it replaced.
+func (s TransactionStatus) AllTransactionHashes() []common.Hash {
+	return append([]common.Hash{s.TransactionHash}, s.PreviousTransactionHashes...)
+}
+
+func (s *TransactionStatus) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	v := struct {
+		Status         TxStatus        `json:"status"`
+		TrackingID     string          `json:"tracking_id"`
+		Hash           *common.Hash    `json:"transaction_hash"`
+		PreviousHashes []common.Hash   `json:"previous_transaction_hashes"`
+		From           *common.Address `json:"from"`
+		To             *common.Address `json:"to"`
+		Data           string          `json:"data"`
+		Value          quantity        `json:"value"`
+		Gas            quantity        `json:"gas"`
+		GasPrice       quantity        `json:"gas_price"`
+		BlockNumber    quantity        `json:"block_number"`
+		Receipt        json.RawMessage `json:"receipt"`
+		CreatedAt      *time.Time      `json:"created_at"`
+		UpdatedAt      *time.Time      `json:"updated_at"`
+	}{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("decode transaction status: %w", err)
+	}
+
+	status := TransactionStatus{
+		Status:                    v.Status,
+		TrackingID:                v.TrackingID,
+		PreviousTransactionHashes: v.PreviousHashes,
+		To:                        v.To,
+		Value:                     v.Value.Int,
+		GasPrice:                  v.GasPrice.Int,
+		BlockNumber:               v.BlockNumber.Int,
+		Raw:                       raw,
+	}
+	if v.Hash != nil {
+		status.TransactionHash = *v.Hash
+	}
+	if v.From != nil {
+		status.From = *v.From
+	}
+	if v.Data != "" {
+		data, err := hexutil.Decode(v.Data)
+		if err != nil {
+			return fmt.Errorf("decode transaction status: invalid 'data' bytes: %w", err)
+		}
+		status.Data = data
+	}
+	if v.Gas.Int != nil {
+		status.Gas = v.Gas.Uint64()
+	}
+	// Receipts missing fields required by go-ethereum are left in Raw only.
+	if len(v.Receipt) > 0 && string(v.Receipt) != "null" {
+		var receipt types.Receipt
+		if err := json.Unmarshal(v.Receipt, &receipt); err == nil {
+			status.Receipt = &receipt
+		}
+	}
+	if v.CreatedAt != nil {
+		status.CreatedAt = *v.CreatedAt
+	}
+	if v.UpdatedAt != nil {
+		status.UpdatedAt = *v.UpdatedAt
+	}
+
+	*s = status
+	return nil
+}
+
+// quantity decodes numbers sent either as JSON numbers, as decimal strings or
+// as 0x prefixed hex strings.
+type quantity struct {
+	*big.Int
+}
+
+func (q *quantity) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+
+	var (
+		n  *big.Int
+		ok bool
+	)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		n, ok = new(big.Int).SetString(s[2:], 16)
+	} else {
+		n, ok = new(big.Int).SetString(s, 10)
+	}
+	if !ok {
+		return fmt.Errorf("invalid number %s", b)
+	}
+	q.Int = n
+	return nil
+}
@@ -1,8 +1,12 @@
 package rockside
 
 import (
+	"math/big"
+	"net/http"
 	"strings"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 func TestValidateTransactionFields(t *testing.T) {
@@ -17,6 +21,9 @@ func TestValidateTransactionFields(t *testing.T) {
 		{tx: Transaction{From: "1245", To: "34567898"}, errContains: "'from' address"},
 		{tx: Transaction{From: validAddress, Data: "456a789"}, errContains: "'data' bytes"},
 		{tx: Transaction{From: validAddress, Value: "456a789"}, errContains: "'value' number"},
+		{tx: Transaction{From: validAddress, Nonce: "12"}, errContains: "'nonce' number"},
+		{tx: Transaction{From: validAddress, Gas: "0xzz"}, errContains: "'gas' number"},
+		{tx: Transaction{From: validAddress, GasPrice: "1000000000"}, errContains: "'gasprice' number"},
 	}
 
 	for i, test := range tests {
@@ -32,3 +39,140 @@ func TestValidateTransactionFields(t *testing.T) {
 		}
 	}
 }
+
+func TestTransactionBuilder(t *testing.T) {
+	from := common.HexToAddress("0x268ba693540A7176ae5d3ba9256A18efbe0A63FF")
+	to := common.HexToAddress("0x618E5C42ECdc63aD84c95D714aFAdA52602Bbac3")
+
+	tx, err := NewTransactionBuilder(from).To(to).Value(big.NewInt(10)).Data([]byte{0x12, 0x34}).Nonce(3).Gas(21000).GasPrice(big.NewInt(1000000000)).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Transaction{From: from.String(), To: to.String(), Value: "0xa", Data: "0x1234", Nonce: "0x3", Gas: "0x5208", GasPrice: "0x3b9aca00"}
+	if got, want := tx, expected; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	ethTx, err := tx.ToEthTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := *ethTx.To(), to; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := ethTx.GasPrice().Int64(), int64(1000000000); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := TransactionFromEth(from, ethTx), tx; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	creation, err := NewTransactionBuilder(from).Data([]byte{0x60}).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ethTx, err = creation.ToEthTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ethTx.To() != nil {
+		t.Fatalf("expected contract creation, got to %v", ethTx.To())
+	}
+	if got, want := TransactionFromEth(from, ethTx).To, ""; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := NewTransactionBuilder(from).Value(big.NewInt(-1)).Build(); err == nil || !strings.Contains(err.Error(), "'value' number") {
+		t.Fatalf("expected invalid value error, got %v", err)
+	}
+	if _, err := NewTransactionBuilder(from).GasPrice(nil).Build(); err == nil || !strings.Contains(err.Error(), "'gasprice' number") {
+		t.Fatalf("expected invalid gasprice error, got %v", err)
+	}
+}
+
+func TestShowTransactionStatus(t *testing.T) {
+	var gotPath, body string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(body))
+	})
+
+	body = `{
+			"tracking_id": "01TRACKING",
+			"transaction_hash": "0x97dfce42248a3f67f5a0660fab117b0ed7cb57af799bdda8854eca5ae5a98e28",
+			"previous_transaction_hashes": ["0x0bfc36e0e4e1df0ee1f2ad4efa2e60c1e4bd5fa8e7a5f2e1d52e2da43c1b2a11"],
+			"from": "0x268ba693540A7176ae5d3ba9256A18efbe0A63FF",
+			"data": "0x1234",
+			"value": "0x0",
+			"gas": "0x5208",
+			"gas_price": "1000000000",
+			"status": "pending",
+			"created_at": "2020-05-04T10:00:00Z",
+			"relayer": "0x618E5C42ECdc63aD84c95D714aFAdA52602Bbac3"
+		}`
+
+	status, err := c.Transaction.Show("01TRACKING")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := gotPath, "/ethereum/ropsten/transactions/01TRACKING"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if got, want := status.Status, TxStatusPending; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := status.From, common.HexToAddress("0x268ba693540A7176ae5d3ba9256A18efbe0A63FF"); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if status.To != nil {
+		t.Fatalf("got %v, want nil", status.To)
+	}
+	if got, want := common.Bytes2Hex(status.Data), "1234"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := status.Gas, uint64(21000); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := status.GasPrice.String(), "1000000000"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := len(status.AllTransactionHashes()), 2; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if status.BlockNumber != nil || status.Receipt != nil {
+		t.Fatalf("expected no block number nor receipt for pending transaction, got %v and %v", status.BlockNumber, status.Receipt)
+	}
+	if got, want := status.CreatedAt.Year(), 2020; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := string(status.Raw["relayer"]), `"0x618E5C42ECdc63aD84c95D714aFAdA52602Bbac3"`; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	body = `{
+		"tracking_id": "01TRACKING",
+		"transaction_hash": "0x97dfce42248a3f67f5a0660fab117b0ed7cb57af799bdda8854eca5ae5a98e28",
+		"status": "mined",
+		"block_number": 12,
+		"receipt": {"status": "0x1", "blockNumber": "0xc", "gasUsed": "0x5208"}
+	}`
+
+	status, err = c.Transaction.Show("01TRACKING")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := status.Status, TxStatusMined; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := status.BlockNumber.Int64(), int64(12); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if status.Receipt != nil {
+		t.Fatalf("expected no receipt for partial receipt, got %v", status.Receipt)
+	}
+	if got, want := string(status.Raw["receipt"]), `{"status": "0x1", "blockNumber": "0xc", "gasUsed": "0x5208"}`; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
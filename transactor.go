@@ -9,7 +9,6 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -67,14 +66,9 @@ func (t *Transactor) EstimateGas(ctx context.Context, call ethereum.CallMsg) (ga
 }
 
 func (t *Transactor) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	resp, err := t.client.Transaction.Send(Transaction{
-		From:     t.rocksideSmartWallet.String(),
-		To:       tx.To().String(),
-		Value:    hexutil.EncodeBig(tx.Value()),
-		Data:     hexutil.Encode(tx.Data()),
-		Gas:      hexutil.EncodeUint64(tx.Gas()),
-		GasPrice: hexutil.EncodeBig(tx.GasPrice()),
-	})
+	rocksideTx := TransactionFromEth(t.rocksideSmartWallet, tx)
+	rocksideTx.Nonce = "" // Rockside manages the nonce
+	resp, err := t.client.Transaction.SendWithContext(ctx, rocksideTx)
 	if err == nil {
 		t.mu.Lock()
 		defer t.mu.Unlock()
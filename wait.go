@@ -0,0 +1,218 @@
+package rockside
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// WaitOptions configures how Transactions.Wait polls Rockside.
+type WaitOptions struct {
+	// Status to wait for (default TxStatusMined).
+	Status TxStatus
+
+	// Confirmations is the number of blocks, counting the one the
+	// transaction is mined in, to wait for once mined. 0 and 1 both return
+	// as soon as the transaction is mined.
+	Confirmations uint64
+
+	// PollInterval is the delay before the first poll is repeated (default
+	// 1s). It grows up to MaxPollInterval (default 15s).
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+}
+
+const (
+	defaultPollInterval    = time.Second
+	defaultMaxPollInterval = 15 * time.Second
+)
+
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.Status == "" {
+		o.Status = TxStatusMined
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = defaultPollInterval
+	}
+	if o.MaxPollInterval < o.PollInterval {
+		o.MaxPollInterval = defaultMaxPollInterval
+		if o.MaxPollInterval < o.PollInterval {
+			o.MaxPollInterval = o.PollInterval
+		}
+	}
+	return o
+}
+
+// WaitMined waits for the transaction with the given tracking ID or hash to
+// be mined and returns its receipt.
+func (t *Transactions) WaitMined(ctx context.Context, trackingIDOrHash string) (*types.Receipt, error) {
+	return t.Wait(ctx, trackingIDOrHash, WaitOptions{})
+}
+
+// Wait polls Rockside until the transaction with the given tracking ID or
+// hash reaches the wanted status and number of confirmations, and returns
+// its final receipt. The receipt is nil when waiting for a pending status.
+//
+// A failed transaction is returned at once with an error wrapping
+// ErrTransactionFailed, and with its receipt when it was mined.
+func (t *Transactions) Wait(ctx context.Context, trackingIDOrHash string, opts WaitOptions) (*types.Receipt, error) {
+	status, err := t.WaitForStatus(ctx, trackingIDOrHash, opts)
+	return status.Receipt, err
+}
+
+// WaitForStatus polls Rockside until the transaction with the given tracking
+// ID or hash reaches the wanted status and number of confirmations.
+//
+// When Rockside speeds the transaction up, the replacing transaction is
+// followed. Transactions unknown to Rockside are looked up by hash on the
+// node.
+func (t *Transactions) WaitForStatus(ctx context.Context, trackingIDOrHash string, opts WaitOptions) (TransactionStatus, error) {
+	opts = opts.withDefaults()
+	backoff := RetryPolicy{InitialBackoff: opts.PollInterval, MaxBackoff: opts.MaxPollInterval, Multiplier: 1.5, Jitter: 0.1}
+
+	id := trackingIDOrHash
+	for attempt := 1; ; attempt++ {
+		status, err := t.poll(ctx, id)
+		if err != nil {
+			return status, err
+		}
+
+		if status.Status == TxStatusReplaced {
+			if next := replacementID(id, status); next != id {
+				t.client.logger.Log(LevelInfo, "following replaced transaction", "network", t.client.network, "from", id, "to", next)
+				id = next
+				continue
+			}
+		}
+
+		done, err := t.reached(ctx, status, opts)
+		if done || err != nil {
+			return status, err
+		}
+
+		if err := sleepContext(ctx, backoff.backoff(attempt)); err != nil {
+			return status, err
+		}
+	}
+}
+
+// poll returns the current status of the transaction, with its receipt once mined.
+func (t *Transactions) poll(ctx context.Context, id string) (TransactionStatus, error) {
+	status, err := t.ShowWithContext(ctx, id)
+	if errors.Is(err, ErrNotFound) && isHash(id) {
+		return t.statusFromNode(ctx, common.HexToHash(id))
+	}
+	if err != nil {
+		return status, err
+	}
+
+	if status.Receipt == nil && (status.Status == TxStatusMined || status.Status == TxStatusFailed) {
+		receipt, err := t.client.RPCClient.TransactionReceipt(ctx, status.TransactionHash)
+		if err != nil && err != ethereum.NotFound {
+			return status, err
+		}
+		status.Receipt = receipt
+		if receipt != nil && status.BlockNumber == nil {
+			status.BlockNumber = receipt.BlockNumber
+		}
+	}
+
+	return status, nil
+}
+
+func (t *Transactions) statusFromNode(ctx context.Context, hash common.Hash) (TransactionStatus, error) {
+	status := TransactionStatus{Status: TxStatusPending, TransactionHash: hash}
+
+	receipt, err := t.client.RPCClient.TransactionReceipt(ctx, hash)
+	if err == ethereum.NotFound {
+		return status, nil
+	}
+	if err != nil {
+		return status, err
+	}
+
+	status.Status = TxStatusMined
+	if receipt.Status == types.ReceiptStatusFailed {
+		status.Status = TxStatusFailed
+	}
+	status.BlockNumber = receipt.BlockNumber
+	status.Receipt = receipt
+	return status, nil
+}
+
+// reached reports whether the transaction has reached the wanted status, and
+// returns an error when it reached another final status.
+func (t *Transactions) reached(ctx context.Context, status TransactionStatus, opts WaitOptions) (bool, error) {
+	// A failed transaction is final whether it was mined or not: Rockside
+	// may fail a transaction before it gets mined.
+	if status.Status == TxStatusMined {
+		if status.Receipt == nil {
+			return false, nil
+		}
+		if opts.Confirmations > 1 {
+			head, err := t.client.RPCClient.BlockNumber(ctx)
+			if err != nil {
+				return false, err
+			}
+			if confirmations(head, status.Receipt.BlockNumber) < opts.Confirmations {
+				return false, nil
+			}
+		}
+	}
+
+	if status.Status == opts.Status {
+		return true, nil
+	}
+
+	switch status.Status {
+	case TxStatusFailed:
+		return true, fmt.Errorf("transaction %s: %w", status.TransactionHash.Hex(), ErrTransactionFailed)
+	case TxStatusMined:
+		return true, fmt.Errorf("transaction %s: mined while waiting for status %s", status.TransactionHash.Hex(), opts.Status)
+	}
+	return false, nil
+}
+
+func confirmations(head uint64, block *big.Int) uint64 {
+	if block == nil || !block.IsUint64() || block.Uint64() > head {
+		return 0
+	}
+	return head - block.Uint64() + 1
+}
+
+// replacementID returns the ID to follow once the transaction with the given ID got replaced.
+func replacementID(id string, status TransactionStatus) string {
+	if status.TrackingID != "" {
+		return status.TrackingID
+	}
+	if status.TransactionHash != (common.Hash{}) {
+		return status.TransactionHash.Hex()
+	}
+	return id
+}
+
+func isHash(s string) bool {
+	if has0xPrefix(s) {
+		s = s[2:]
+	}
+	return len(s) == 2*common.HashLength && isHex(s)
+}
+
+func has0xPrefix(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
+func isHex(s string) bool {
+	for _, c := range []byte(s) {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package rockside_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+func TestWait(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	smartWallet := srv.NewWallet(t).SmartWallet.String()
+	srv.MineAll()
+
+	send := func(t *testing.T) rockside.ContractCreationResponse {
+		sent, err := client.Transaction.Send(rockside.Transaction{From: smartWallet, To: smartWallet, Value: "0x0"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sent
+	}
+	later := func(f func()) {
+		go func() {
+			time.Sleep(30 * time.Millisecond)
+			f()
+		}()
+	}
+	opts := rockside.WaitOptions{PollInterval: 5 * time.Millisecond, MaxPollInterval: 10 * time.Millisecond}
+
+	t.Run("mined", func(t *testing.T) {
+		sent := send(t)
+		later(func() { srv.Mine(sent.TrackingID) })
+
+		receipt, err := client.Transaction.Wait(context.Background(), sent.TrackingID, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := receipt.TxHash, common.HexToHash(sent.TransactionHash); got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("replaced", func(t *testing.T) {
+		sent := send(t)
+		replacement, err := srv.Replace(sent.TrackingID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		later(func() { srv.Mine(sent.TrackingID) })
+
+		receipt, err := client.Transaction.Wait(context.Background(), sent.TransactionHash, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := receipt.TxHash, replacement; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		sent := send(t)
+		later(func() { srv.Fail(sent.TrackingID) })
+
+		receipt, err := client.Transaction.Wait(context.Background(), sent.TrackingID, opts)
+		if !errors.Is(err, rockside.ErrTransactionFailed) {
+			t.Fatalf("expected transaction failed error, got %v", err)
+		}
+		if got, want := receipt.Status, uint64(0); got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("failed before mined", func(t *testing.T) {
+		sent := send(t)
+		srv.AddHook(func(w http.ResponseWriter, r *http.Request) bool {
+			if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/"+sent.TrackingID) {
+				return false
+			}
+			w.Write([]byte(`{"status": "failed", "tracking_id": "` + sent.TrackingID + `", "transaction_hash": "` + sent.TransactionHash + `", "receipt": null}`))
+			return true
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		receipt, err := client.Transaction.Wait(ctx, sent.TrackingID, opts)
+		if !errors.Is(err, rockside.ErrTransactionFailed) {
+			t.Fatalf("expected transaction failed error, got %v", err)
+		}
+		if receipt != nil {
+			t.Fatalf("got receipt %v, want none", receipt)
+		}
+	})
+
+	t.Run("confirmations", func(t *testing.T) {
+		sent := send(t)
+		srv.Mine(sent.TrackingID)
+		later(func() { srv.AdvanceBlocks(2) })
+
+		opts := opts
+		opts.Confirmations = 3
+		start := time.Now()
+		if _, err := client.Transaction.Wait(context.Background(), sent.TrackingID, opts); err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+			t.Fatalf("returned after %v, before confirmations", elapsed)
+		}
+	})
+
+	t.Run("pending status", func(t *testing.T) {
+		sent := send(t)
+
+		opts := opts
+		opts.Status = rockside.TxStatusPending
+		status, err := client.Transaction.WaitForStatus(context.Background(), sent.TrackingID, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := status.TrackingID, sent.TrackingID; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		sent := send(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+		if _, err := client.Transaction.Wait(ctx, sent.TrackingID, opts); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,325 @@
+package rockside
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxStatusConfirmed is reported by a Watcher once a mined transaction has
+// reached the wanted number of confirmations. It is not returned by Rockside.
+const TxStatusConfirmed TxStatus = "confirmed"
+
+// WatchEvent is a status transition of a transaction tracked by a Watcher.
+type WatchEvent struct {
+	// ID is the tracking ID or hash given to Watcher.Add.
+	ID string
+
+	Previous TxStatus
+	Status   TxStatus
+
+	// Transaction is the status returned by Rockside. When a transaction
+	// got replaced, it holds the replacing transaction.
+	Transaction TransactionStatus
+}
+
+// WatcherOptions configures a Watcher.
+type WatcherOptions struct {
+	// PollInterval is the delay between two polling rounds (default 5s).
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of transactions polled in a round
+	// (default 100). Transactions are polled in turn when more are watched.
+	BatchSize int
+
+	// Concurrency is the number of requests in flight during a round (default 4).
+	Concurrency int
+
+	// RateLimit is the maximum number of requests per second (default 10).
+	RateLimit float64
+
+	// Confirmations is the number of blocks, counting the one the
+	// transaction is mined in, before reporting it confirmed.
+	Confirmations uint64
+
+	// Handler receives the events when set. Otherwise they are sent on the
+	// channel returned by Events.
+	Handler func(WatchEvent)
+
+	// EventBuffer is the capacity of the events channel (default 100).
+	EventBuffer int
+}
+
+func (o WatcherOptions) withDefaults() WatcherOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = 5 * time.Second
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	if o.RateLimit <= 0 {
+		o.RateLimit = 10
+	}
+	if o.EventBuffer <= 0 {
+		o.EventBuffer = 100
+	}
+	return o
+}
+
+// Watcher tracks many transactions and reports their status transitions
+// (pending, mined, then confirmed, failed or replaced), each one once.
+// Confirmed and failed transactions stop being watched.
+//
+//	w := rockside.NewWatcher(client, rockside.WatcherOptions{Confirmations: 3})
+//	w.Add(resp.TrackingID)
+//	go w.Run(ctx)
+//	for ev := range w.Events() { ... }
+type Watcher struct {
+	client *Client
+	opts   WatcherOptions
+	events chan WatchEvent
+
+	mu      sync.Mutex
+	watched map[string]*watchedTx
+	order   []string
+	next    int
+}
+
+type watchedTx struct {
+	pollID string
+	status TxStatus
+	hash   common.Hash
+}
+
+// NewWatcher returns a watcher polling Rockside with the given client. Start it with Run.
+func NewWatcher(c *Client, opts WatcherOptions) *Watcher {
+	opts = opts.withDefaults()
+	return &Watcher{
+		client:  c,
+		opts:    opts,
+		events:  make(chan WatchEvent, opts.EventBuffer),
+		watched: make(map[string]*watchedTx),
+	}
+}
+
+// Add watches the transaction with the given tracking ID or hash.
+func (w *Watcher) Add(trackingIDOrHash string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.watched[trackingIDOrHash]; ok {
+		return
+	}
+	w.watched[trackingIDOrHash] = &watchedTx{pollID: trackingIDOrHash}
+	w.order = append(w.order, trackingIDOrHash)
+}
+
+// Remove stops watching the transaction with the given tracking ID or hash.
+func (w *Watcher) Remove(trackingIDOrHash string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.remove(trackingIDOrHash)
+}
+
+func (w *Watcher) remove(id string) {
+	if _, ok := w.watched[id]; !ok {
+		return
+	}
+	delete(w.watched, id)
+	for i, o := range w.order {
+		if o == id {
+			w.order = append(w.order[:i], w.order[i+1:]...)
+			if w.next > i {
+				w.next--
+			}
+			break
+		}
+	}
+}
+
+// Len returns the number of transactions watched.
+func (w *Watcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.watched)
+}
+
+// Events returns the channel receiving the events when no Handler is set.
+// It is closed when Run returns.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// Run polls the watched transactions until the context is done, and
+// returns the context error.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+
+	limiter := time.NewTicker(time.Duration(float64(time.Second) / w.opts.RateLimit))
+	defer limiter.Stop()
+
+	for {
+		w.round(ctx, limiter.C)
+		if err := sleepContext(ctx, w.opts.PollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+type pollResult struct {
+	id     string
+	status TransactionStatus
+	err    error
+}
+
+func (w *Watcher) round(ctx context.Context, limiter <-chan time.Time) {
+	batch := w.batch()
+	if len(batch) == 0 {
+		return
+	}
+
+	results := make([]pollResult, len(batch))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < w.opts.Concurrency && i < len(batch); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				select {
+				case <-limiter:
+				case <-ctx.Done():
+					results[j].err = ctx.Err()
+					continue
+				}
+				results[j].status, results[j].err = w.client.Transaction.poll(ctx, results[j].id)
+			}
+		}()
+	}
+	for i, b := range batch {
+		results[i].id = b.pollID
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var head *uint64
+	for i, res := range results {
+		if ctx.Err() != nil {
+			return
+		}
+		if res.err != nil {
+			w.client.logger.Log(LevelWarn, "cannot poll watched transaction", "network", w.client.network, "id", batch[i].id, "error", res.err)
+			continue
+		}
+		if res.status.Status == TxStatusMined && w.opts.Confirmations > 1 && head == nil {
+			n, err := w.client.RPCClient.BlockNumber(ctx)
+			if err != nil {
+				w.client.logger.Log(LevelWarn, "cannot get block number", "network", w.client.network, "error", err)
+				continue
+			}
+			head = &n
+		}
+		w.update(ctx, batch[i].id, res.status, head)
+	}
+}
+
+type batchItem struct {
+	id     string
+	pollID string
+}
+
+// batch returns the next transactions to poll, in turn.
+func (w *Watcher) batch() []batchItem {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := len(w.order)
+	if n > w.opts.BatchSize {
+		n = w.opts.BatchSize
+	}
+	batch := make([]batchItem, 0, n)
+	for i := 0; i < n; i++ {
+		if w.next >= len(w.order) {
+			w.next = 0
+		}
+		id := w.order[w.next]
+		batch = append(batch, batchItem{id: id, pollID: w.watched[id].pollID})
+		w.next++
+	}
+	return batch
+}
+
+// update emits the transitions from the last reported status to the polled one.
+func (w *Watcher) update(ctx context.Context, id string, status TransactionStatus, head *uint64) {
+	w.mu.Lock()
+	tx, ok := w.watched[id]
+	if !ok {
+		w.mu.Unlock()
+		return
+	}
+	var transitions []TxStatus
+	last := tx.status
+	transition := func(s TxStatus) {
+		transitions = append(transitions, s)
+		last = s
+	}
+
+	replaced := status.Status == TxStatusReplaced ||
+		tx.hash != (common.Hash{}) && status.TransactionHash != (common.Hash{}) && status.TransactionHash != tx.hash
+	if replaced && last != TxStatusReplaced {
+		transition(TxStatusReplaced)
+	}
+	if status.Status == TxStatusReplaced {
+		tx.pollID = replacementID(tx.pollID, status)
+	} else {
+		switch status.Status {
+		case TxStatusPending:
+			if last != TxStatusPending {
+				transition(TxStatusPending)
+			}
+		case TxStatusMined:
+			if status.Receipt == nil {
+				break
+			}
+			if last != TxStatusMined {
+				transition(TxStatusMined)
+			}
+			if w.opts.Confirmations <= 1 || head != nil && confirmations(*head, status.Receipt.BlockNumber) >= w.opts.Confirmations {
+				transition(TxStatusConfirmed)
+			}
+		case TxStatusFailed:
+			transition(TxStatusFailed)
+		}
+		if status.TransactionHash != (common.Hash{}) {
+			tx.hash = status.TransactionHash
+		}
+	}
+
+	previous := tx.status
+	tx.status = last
+	if last == TxStatusConfirmed || last == TxStatusFailed {
+		w.remove(id)
+	}
+	w.mu.Unlock()
+
+	for _, s := range transitions {
+		w.emit(ctx, WatchEvent{ID: id, Previous: previous, Status: s, Transaction: status})
+		previous = s
+	}
+}
+
+func (w *Watcher) emit(ctx context.Context, ev WatchEvent) {
+	if w.opts.Handler != nil {
+		w.opts.Handler(ev)
+		return
+	}
+	select {
+	case w.events <- ev:
+	case <-ctx.Done():
+	}
+}
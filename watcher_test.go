@@ -0,0 +1,153 @@
+package rockside_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/rocksidetest"
+)
+
+func TestWatcher(t *testing.T) {
+	srv := rocksidetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	smartWallet := srv.NewWallet(t).SmartWallet.String()
+	srv.MineAll()
+
+	send := func(t *testing.T) rockside.ContractCreationResponse {
+		sent, err := client.Transaction.Send(rockside.Transaction{From: smartWallet, To: smartWallet, Value: "0x0"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sent
+	}
+	next := func(t *testing.T, events <-chan rockside.WatchEvent) rockside.WatchEvent {
+		t.Helper()
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+		return rockside.WatchEvent{}
+	}
+
+	t.Run("channel", func(t *testing.T) {
+		mined, failed, replaced := send(t), send(t), send(t)
+
+		w := rockside.NewWatcher(client, rockside.WatcherOptions{PollInterval: 5 * time.Millisecond, RateLimit: 1000, BatchSize: 2, Confirmations: 2})
+		w.Add(mined.TrackingID)
+		w.Add(failed.TrackingID)
+		w.Add(replaced.TransactionHash)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- w.Run(ctx) }()
+
+		got := make(map[string][]rockside.TxStatus)
+		receive := func(until func() bool) {
+			for !until() {
+				ev := next(t, w.Events())
+				got[ev.ID] = append(got[ev.ID], ev.Status)
+			}
+		}
+
+		receive(func() bool { return len(got) == 3 })
+
+		srv.Mine(mined.TrackingID)
+		srv.Fail(failed.TrackingID)
+		srv.Replace(replaced.TrackingID)
+		receive(func() bool { return len(got[replaced.TransactionHash]) == 3 })
+
+		srv.Mine(replaced.TrackingID)
+		srv.AdvanceBlocks(1)
+		receive(func() bool {
+			return len(got[mined.TrackingID])+len(got[failed.TrackingID])+len(got[replaced.TransactionHash]) == 10
+		})
+
+		expected := map[string][]rockside.TxStatus{
+			mined.TrackingID:         {rockside.TxStatusPending, rockside.TxStatusMined, rockside.TxStatusConfirmed},
+			failed.TrackingID:        {rockside.TxStatusPending, rockside.TxStatusFailed},
+			replaced.TransactionHash: {rockside.TxStatusPending, rockside.TxStatusReplaced, rockside.TxStatusPending, rockside.TxStatusMined, rockside.TxStatusConfirmed},
+		}
+		for id, want := range expected {
+			if got, want := got[id], want; !equalStatuses(got, want) {
+				t.Fatalf("%s: got %v, want %v", id, got, want)
+			}
+		}
+		if got, want := w.Len(), 0; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Fatalf("got %v, want %v", err, context.Canceled)
+		}
+		if _, ok := <-w.Events(); ok {
+			t.Fatal("expected events channel closed")
+		}
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		sent := send(t)
+
+		var (
+			mu     sync.Mutex
+			events []rockside.WatchEvent
+		)
+		confirmed := make(chan struct{})
+		w := rockside.NewWatcher(client, rockside.WatcherOptions{
+			PollInterval: 5 * time.Millisecond,
+			RateLimit:    1000,
+			Handler: func(ev rockside.WatchEvent) {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, ev)
+				if ev.Status == rockside.TxStatusConfirmed {
+					close(confirmed)
+				}
+			},
+		})
+		w.Add(sent.TrackingID)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go w.Run(ctx)
+
+		time.Sleep(30 * time.Millisecond)
+		srv.Mine(sent.TrackingID)
+		select {
+		case <-confirmed:
+		case <-time.After(2 * time.Second):
+			t.Fatal("timeout waiting for confirmation")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if got, want := len(events), 3; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := events[2].Previous, rockside.TxStatusMined; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if events[2].Transaction.Receipt == nil {
+			t.Fatal("expected receipt in confirmed event")
+		}
+	})
+}
+
+func equalStatuses(a, b []rockside.TxStatus) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,268 @@
+// Package webhook receives the transaction status callbacks sent by Rockside.
+//
+//	h := webhook.NewHandler(webhook.HMACSHA256(secret), func(ctx context.Context, status rockside.TransactionStatus) error {
+//		// handle status update
+//		return nil
+//	})
+//	http.Handle("/rockside/callbacks", h)
+//
+// Callbacks are delivered at least once by Rockside. The handler drops the
+// ones already handled successfully, and answers with an error status when
+// the user callback fails so that Rockside delivers it again.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/rocksideio/rockside-sdk-go"
+)
+
+const (
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the callback body.
+	SignatureHeader = "X-Rockside-Signature"
+
+	// SecretHeader holds the shared secret.
+	SecretHeader = "X-Rockside-Secret"
+
+	// EventIDHeader identifies a callback across deliveries, when sent.
+	EventIDHeader = "X-Rockside-Event-Id"
+
+	defaultMaxBodySize = 1 << 20
+)
+
+// ErrInvalidSignature is returned by verifiers for callbacks not sent by Rockside.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Callback handles a transaction status update. Returning an error makes
+// Rockside deliver the update again.
+type Callback func(ctx context.Context, status rockside.TransactionStatus) error
+
+// Verifier checks that a callback was sent by Rockside.
+type Verifier interface {
+	Verify(r *http.Request, body []byte) error
+}
+
+type hmacVerifier struct {
+	secret []byte
+}
+
+// HMACSHA256 verifies the HMAC-SHA256 of the callback body, computed with the
+// given secret and sent hex encoded in the X-Rockside-Signature header,
+// optionally prefixed with "sha256=". Every callback is rejected when the
+// secret is empty.
+func HMACSHA256(secret []byte) Verifier {
+	return hmacVerifier{secret: secret}
+}
+
+func (v hmacVerifier) Verify(r *http.Request, body []byte) error {
+	if len(v.secret) == 0 {
+		return ErrInvalidSignature
+	}
+	sig := strings.TrimPrefix(r.Header.Get(SignatureHeader), "sha256=")
+	got, err := hex.DecodeString(sig)
+	if err != nil || len(got) == 0 {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+type sharedSecretVerifier struct {
+	secret []byte
+}
+
+// SharedSecret verifies the secret sent in the X-Rockside-Secret header.
+func SharedSecret(secret string) Verifier {
+	return sharedSecretVerifier{secret: []byte(secret)}
+}
+
+func (v sharedSecretVerifier) Verify(r *http.Request, body []byte) error {
+	got := []byte(r.Header.Get(SecretHeader))
+	if len(v.secret) == 0 || subtle.ConstantTimeCompare(got, v.secret) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Store records the callbacks handled successfully.
+type Store interface {
+	Seen(key string) (bool, error)
+	MarkSeen(key string) error
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithStore sets the store used to drop duplicate callbacks (default an
+// in-memory store of the last 10000 callbacks).
+func WithStore(s Store) Option {
+	return func(h *Handler) {
+		h.store = s
+	}
+}
+
+// WithMaxBodySize sets the maximum size of a callback body (default 1MB).
+func WithMaxBodySize(n int64) Option {
+	return func(h *Handler) {
+		h.maxBodySize = n
+	}
+}
+
+// Handler is an http.Handler receiving Rockside transaction status callbacks.
+type Handler struct {
+	verifier    Verifier
+	callback    Callback
+	store       Store
+	maxBodySize int64
+
+	mu       sync.Mutex
+	inFlight map[string]bool
+}
+
+// NewHandler returns a handler verifying callbacks with the given verifier
+// and dispatching them to the given callback.
+func NewHandler(verifier Verifier, callback Callback, opts ...Option) *Handler {
+	h := &Handler{
+		verifier:    verifier,
+		callback:    callback,
+		store:       NewMemoryStore(10000),
+		maxBodySize: defaultMaxBodySize,
+		inFlight:    make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, h.maxBodySize+1))
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > h.maxBodySize {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := h.verifier.Verify(r, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var status rockside.TransactionStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	key := eventKey(r, status)
+
+	seen, err := h.store.Seen(key)
+	if err != nil {
+		http.Error(w, "cannot check duplicate", http.StatusInternalServerError)
+		return
+	}
+	if seen {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if !h.begin(key) {
+		http.Error(w, "callback already being handled", http.StatusConflict)
+		return
+	}
+	defer h.end(key)
+
+	if err := h.callback(r.Context(), status); err != nil {
+		http.Error(w, fmt.Sprintf("callback failed: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.store.MarkSeen(key); err != nil {
+		http.Error(w, "cannot record callback", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) begin(key string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.inFlight[key] {
+		return false
+	}
+	h.inFlight[key] = true
+	return true
+}
+
+func (h *Handler) end(key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.inFlight, key)
+}
+
+func eventKey(r *http.Request, status rockside.TransactionStatus) string {
+	if id := r.Header.Get(EventIDHeader); id != "" {
+		return id
+	}
+	return fmt.Sprintf("%s/%s/%s", status.TrackingID, status.TransactionHash.Hex(), status.Status)
+}
+
+// MemoryStore is an in-memory Store remembering a bounded number of callbacks.
+type MemoryStore struct {
+	mu    sync.Mutex
+	size  int
+	seen  map[string]bool
+	order []string
+}
+
+// NewMemoryStore returns a store remembering the last size callbacks.
+func NewMemoryStore(size int) *MemoryStore {
+	return &MemoryStore{size: size, seen: make(map[string]bool)}
+}
+
+func (s *MemoryStore) Seen(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seen[key], nil
+}
+
+func (s *MemoryStore) MarkSeen(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen[key] {
+		return nil
+	}
+	s.seen[key] = true
+	s.order = append(s.order, key)
+	if s.size > 0 && len(s.order) > s.size {
+		delete(s.seen, s.order[0])
+		s.order = s.order[1:]
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rocksideio/rockside-sdk-go"
+	"github.com/rocksideio/rockside-sdk-go/webhook"
+)
+
+const payload = `{"tracking_id":"01TRACKING","transaction_hash":"0x97dfce42248a3f67f5a0660fab117b0ed7cb57af799bdda8854eca5ae5a98e28","status":"mined","block_number":12}`
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHandler(t *testing.T) {
+	var (
+		received []rockside.TransactionStatus
+		failNext bool
+	)
+	h := webhook.NewHandler(webhook.HMACSHA256([]byte("secret")), func(ctx context.Context, status rockside.TransactionStatus) error {
+		if failNext {
+			failNext = false
+			return errors.New("database down")
+		}
+		received = append(received, status)
+		return nil
+	})
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	post := func(body string, header map[string]string) int {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	tests := []struct {
+		body     string
+		header   map[string]string
+		failNext bool
+		status   int
+		received int
+	}{
+		{body: payload, status: http.StatusUnauthorized},
+		{body: payload, header: map[string]string{webhook.SignatureHeader: sign("other", payload)}, status: http.StatusUnauthorized},
+		{body: `{"status":`, header: map[string]string{webhook.SignatureHeader: sign("secret", `{"status":`)}, status: http.StatusBadRequest},
+		{body: payload, header: map[string]string{webhook.SignatureHeader: sign("secret", payload)}, failNext: true, status: http.StatusInternalServerError},
+		{body: payload, header: map[string]string{webhook.SignatureHeader: "sha256=" + sign("secret", payload)}, status: http.StatusOK, received: 1},
+		{body: payload, header: map[string]string{webhook.SignatureHeader: sign("secret", payload)}, status: http.StatusOK, received: 1},
+		{body: payload, header: map[string]string{webhook.SignatureHeader: sign("secret", payload), webhook.EventIDHeader: "evt-1"}, status: http.StatusOK, received: 2},
+	}
+
+	for i, test := range tests {
+		failNext = test.failNext
+		if got, want := post(test.body, test.header), test.status; got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+		if got, want := len(received), test.received; got != want {
+			t.Fatalf("case %d: got %v, want %v", i+1, got, want)
+		}
+	}
+
+	if got, want := received[0].Status, rockside.TxStatusMined; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := received[0].BlockNumber.Uint64(), uint64(12); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got, want := resp.StatusCode, http.StatusMethodNotAllowed; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestHMACSHA256(t *testing.T) {
+	tests := []struct {
+		secret    []byte
+		signature string
+		err       error
+	}{
+		{secret: []byte("secret"), signature: sign("secret", payload)},
+		{secret: []byte("secret"), signature: "sha256=" + sign("secret", payload)},
+		{secret: []byte("secret"), signature: sign("other", payload), err: webhook.ErrInvalidSignature},
+		{secret: []byte(""), signature: sign("", payload), err: webhook.ErrInvalidSignature},
+		{secret: nil, signature: sign("", payload), err: webhook.ErrInvalidSignature},
+	}
+	for i, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+		req.Header.Set(webhook.SignatureHeader, test.signature)
+		if err := webhook.HMACSHA256(test.secret).Verify(req, []byte(payload)); err != test.err {
+			t.Fatalf("case %d: got %v, want %v", i+1, err, test.err)
+		}
+	}
+}
+
+func TestSharedSecret(t *testing.T) {
+	v := webhook.SharedSecret("secret")
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	if err := v.Verify(req, nil); !errors.Is(err, webhook.ErrInvalidSignature) {
+		t.Fatalf("expected invalid signature, got %v", err)
+	}
+	req.Header.Set(webhook.SecretHeader, "secret")
+	if err := v.Verify(req, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := webhook.NewMemoryStore(2)
+	s.MarkSeen("a")
+	s.MarkSeen("b")
+	s.MarkSeen("c")
+
+	for key, want := range map[string]bool{"a": false, "b": true, "c": true} {
+		if got, _ := s.Seen(key); got != want {
+			t.Fatalf("%s: got %v, want %v", key, got, want)
+		}
+	}
+}